@@ -0,0 +1,177 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus/introspect"
+
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Introspection is the parsed introspection data of an object: its
+// interfaces, with their methods, properties (type signature and access) and
+// signals.
+//
+type Introspection struct {
+	*introspect.Node
+}
+
+// Introspect queries the object interfaces, to check which methods,
+// properties and signals the server provides.
+//
+// The introspection data is declared per interface: all objects implementing
+// an interface report the same members. It doesn't tell whether a property
+// has a value for this object (Latency of a device without latency querying,
+// ActivePort of a device without ports), the property call still returns an
+// error in that case.
+//
+//   dev := pulse.Device(sink)
+//   in, e := dev.Introspect()
+//   if e == nil && in.CanWrite(pulseaudio.DbusInterface+".Device", "ActivePort") {
+//   	e = dev.Set("ActivePort", port)
+//   }
+//
+func (dev *Object) Introspect() (Introspection, error) {
+	node, e := introspect.Call(dev.BusObject)
+	return Introspection{node}, e
+}
+
+// Interface returns the interface with the given name, or nil if the object
+// doesn't implement it.
+//
+func (in Introspection) Interface(name string) *introspect.Interface {
+	if in.Node == nil {
+		return nil
+	}
+	for i := range in.Interfaces {
+		if in.Interfaces[i].Name == name {
+			return &in.Interfaces[i]
+		}
+	}
+	return nil
+}
+
+// Property returns the property of an interface, or nil if not found.
+//
+func (in Introspection) Property(iface, name string) *introspect.Property {
+	if found := in.Interface(iface); found != nil {
+		for i := range found.Properties {
+			if found.Properties[i].Name == name {
+				return &found.Properties[i]
+			}
+		}
+	}
+	return nil
+}
+
+// HasProperty returns whether the interface has the property.
+//
+func (in Introspection) HasProperty(iface, name string) bool {
+	return in.Property(iface, name) != nil
+}
+
+// CanWrite returns whether the property of the interface is writable.
+//
+func (in Introspection) CanWrite(iface, name string) bool {
+	prop := in.Property(iface, name)
+	return prop != nil && strings.Contains(prop.Access, "write")
+}
+
+// HasMethod returns whether the interface has the method.
+//
+func (in Introspection) HasMethod(iface, name string) bool {
+	if found := in.Interface(iface); found != nil {
+		for _, method := range found.Methods {
+			if method.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasSignal returns whether the interface has the signal.
+//
+func (in Introspection) HasSignal(iface, name string) bool {
+	if found := in.Interface(iface); found != nil {
+		for _, signal := range found.Signals {
+			if signal.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//
+//------------------------------------------------------------[ CAPABILITIES ]--
+
+// Capabilities summarizes the server features, to enable code depending on
+// the server version or loaded extensions.
+//
+type Capabilities struct {
+	Name              string         // Server name.
+	Version           string         // Server version.
+	InterfaceRevision uint32         // Revision of the core Dbus interface.
+	Extensions        map[string]int // Extensions versions, indexed by name without version. See ExtensionVersion.
+}
+
+// HasExtension returns whether the extension is loaded, with at least the
+// given version. name is the extension name without version.
+//
+//   caps.HasExtension("org.PulseAudio.Ext.StreamRestore", 1)
+//
+func (caps Capabilities) HasExtension(name string, version int) bool {
+	found, ok := caps.Extensions[name]
+	return ok && found >= version
+}
+
+// Capabilities queries the server identity, interface revision and loaded
+// extensions.
+//
+func (pulse *Client) Capabilities() (caps Capabilities, e error) {
+	core := pulse.Core()
+	caps.Name, e = core.String("Name")
+	if e != nil {
+		return caps, e
+	}
+	caps.Version, e = core.String("Version")
+	if e != nil {
+		return caps, e
+	}
+	caps.InterfaceRevision, e = core.Uint32("InterfaceRevision")
+	if e != nil {
+		return caps, e
+	}
+	exts, e := core.ListString("Extensions")
+	if e != nil {
+		return caps, e
+	}
+
+	caps.Extensions = make(map[string]int, len(exts))
+	for _, ext := range exts {
+		name, version := ExtensionVersion(ext)
+		caps.Extensions[name] = version
+	}
+	return caps, nil
+}
+
+// ExtensionVersion splits an extension interface name in its name without
+// version, and its version (0 if not set).
+//
+//   ExtensionVersion("org.PulseAudio.Ext.Equalizing1.Manager")
+//   // "org.PulseAudio.Ext.Equalizing.Manager", 1
+//
+func ExtensionVersion(ext string) (name string, version int) {
+	parts := strings.Split(ext, ".")
+	for i, part := range parts {
+		base := strings.TrimRightFunc(part, unicode.IsDigit)
+		if base == part || base == "" {
+			continue
+		}
+		version, _ = strconv.Atoi(part[len(base):])
+		parts[i] = base
+		break
+	}
+	return strings.Join(parts, "."), version
+}
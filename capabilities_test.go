@@ -0,0 +1,68 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus/introspect"
+
+	"github.com/sqp/pulseaudio"
+
+	"encoding/xml"
+	"testing"
+)
+
+func TestExtensionVersion(t *testing.T) {
+	for ext, want := range map[string]struct {
+		name    string
+		version int
+	}{
+		"org.PulseAudio.Ext.StreamRestore1":      {"org.PulseAudio.Ext.StreamRestore", 1},
+		"org.PulseAudio.Ext.Equalizing1.Manager": {"org.PulseAudio.Ext.Equalizing.Manager", 1},
+		"org.PulseAudio.Ext.Ladspa12":            {"org.PulseAudio.Ext.Ladspa", 12},
+		"org.PulseAudio.Ext.NoVersion":           {"org.PulseAudio.Ext.NoVersion", 0},
+		"org.PulseAudio.Ext.Test2.Sub3":          {"org.PulseAudio.Ext.Test.Sub3", 2},
+	} {
+		name, version := pulseaudio.ExtensionVersion(ext)
+		if name != want.name || version != want.version {
+			t.Errorf("extension %s: got %s %d, want %s %d", ext, name, version, want.name, want.version)
+		}
+	}
+
+	caps := pulseaudio.Capabilities{Extensions: map[string]int{"org.PulseAudio.Ext.StreamRestore": 1}}
+	if !caps.HasExtension("org.PulseAudio.Ext.StreamRestore", 1) || caps.HasExtension("org.PulseAudio.Ext.StreamRestore", 2) {
+		t.Error("has extension: wrong version check")
+	}
+}
+
+func TestIntrospection(t *testing.T) {
+	const data = `<node>
+ <interface name="org.PulseAudio.Core1.Device">
+  <method name="Suspend"><arg name="suspend" type="b" direction="in"/></method>
+  <property name="Latency" type="t" access="read"/>
+  <property name="Mute" type="b" access="readwrite"/>
+  <signal name="MuteUpdated"><arg name="muted" type="b"/></signal>
+ </interface>
+</node>`
+	node := &introspect.Node{}
+	e := xml.Unmarshal([]byte(data), node)
+	if e != nil {
+		t.Fatal("parse:", e)
+	}
+	in := pulseaudio.Introspection{Node: node}
+	iface := pulseaudio.DbusInterface + ".Device"
+
+	if prop := in.Property(iface, "Latency"); prop == nil || prop.Type != "t" {
+		t.Errorf("property Latency: got %v", prop)
+	}
+	switch {
+	case !in.HasProperty(iface, "Mute") || in.HasProperty(iface, "ActivePort"):
+		t.Error("has property: wrong result")
+	case !in.CanWrite(iface, "Mute") || in.CanWrite(iface, "Latency"):
+		t.Error("can write: wrong result")
+	case !in.HasMethod(iface, "Suspend") || in.HasMethod(iface, "Kill"):
+		t.Error("has method: wrong result")
+	case !in.HasSignal(iface, "MuteUpdated") || in.HasSignal(pulseaudio.DbusInterface+".Stream", "MuteUpdated"):
+		t.Error("has signal: wrong result")
+	}
+	if (pulseaudio.Introspection{}).Interface(iface) != nil {
+		t.Error("empty introspection: found an interface")
+	}
+}
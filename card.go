@@ -0,0 +1,160 @@
+package pulseaudio
+
+import "github.com/godbus/dbus"
+
+// Card controls a pulseaudio card.
+//
+// Methods list:
+//   !GetProfileByName   Find the card profile with the given name.
+//     string:             Profile name.
+//     out: ObjectPath:    Card profile object.
+//
+// Properties list:
+//   Uint32
+//     Index             The card index.
+//
+//   String
+//     Name              The card name.
+//     Driver            The driver that implements the card object.
+//
+//   ObjectPath
+//     !OwnerModule      The module that owns this card. It's not guaranteed that any
+//                       module claims ownership; in such case this property does not exist.
+//     !ActiveProfile RW The currently active profile.
+//
+//   ListPath
+//     Sinks             The sinks that belong to this card.
+//     Sources           The sources that belong to this card.
+//     Profiles          The available profiles for this card.
+//
+//   MapString
+//     !PropertyList     The card's property list.
+//
+func (pulse *Client) Card(path dbus.ObjectPath) *Card {
+	return &Card{pulse.newObject(DbusInterface+".Card", path), pulse}
+}
+
+// CardProfile controls a pulseaudio card profile.
+//
+// Properties list:
+//   Uint32
+//     Index          The profile index.
+//     Sinks          The number of sinks this profile creates.
+//     Sources        The number of sources this profile creates.
+//     Priority       The higher the priority, the better the profile.
+//
+//   String
+//     Name           The profile name.
+//     Description    The profile human readable description.
+//
+//   Boolean
+//     Available      Whether the profile is available (since interface revision 1).
+//
+func (pulse *Client) CardProfile(path dbus.ObjectPath) *CardProfile {
+	return &CardProfile{pulse.newObject(DbusInterface+".CardProfile", path)}
+}
+
+// Cards returns all cards currently available.
+//
+func (pulse *Client) Cards() ([]*Card, error) {
+	paths, e := pulse.Core().ListPath("Cards")
+	if e != nil {
+		return nil, e
+	}
+	cards := make([]*Card, len(paths))
+	for i, path := range paths {
+		cards[i] = pulse.Card(path)
+	}
+	return cards, nil
+}
+
+// CardByName finds a card by its name.
+//
+func (pulse *Client) CardByName(name string) (*Card, error) {
+	var path dbus.ObjectPath
+	e := pulse.Core().Call(DbusInterface+".GetCardByName", 0, name).Store(&path)
+	if e != nil {
+		return nil, e
+	}
+	return pulse.Card(path), nil
+}
+
+// Card is a typed access to a pulseaudio card.
+// See Client.Card for the properties list.
+//
+type Card struct {
+	*Object
+	pulse *Client
+}
+
+// Name returns the card name.
+//
+func (card *Card) Name() (string, error) {
+	return card.String("Name")
+}
+
+// Profiles returns the available profiles of the card.
+//
+func (card *Card) Profiles() ([]*CardProfile, error) {
+	paths, e := card.ListPath("Profiles")
+	if e != nil {
+		return nil, e
+	}
+	profiles := make([]*CardProfile, len(paths))
+	for i, path := range paths {
+		profiles[i] = card.pulse.CardProfile(path)
+	}
+	return profiles, nil
+}
+
+// ActiveProfile returns the currently active profile.
+//
+func (card *Card) ActiveProfile() (*CardProfile, error) {
+	path, e := card.ObjectPath("ActiveProfile")
+	if e != nil {
+		return nil, e
+	}
+	return card.pulse.CardProfile(path), nil
+}
+
+// SetActiveProfile sets the active profile.
+//
+func (card *Card) SetActiveProfile(profile dbus.ObjectPath) error {
+	return card.Set("ActiveProfile", profile)
+}
+
+// ProfileByName finds a profile of the card by its name.
+//
+func (card *Card) ProfileByName(name string) (*CardProfile, error) {
+	var path dbus.ObjectPath
+	e := card.Call(card.prefix+".GetProfileByName", 0, name).Store(&path)
+	if e != nil {
+		return nil, e
+	}
+	return card.pulse.CardProfile(path), nil
+}
+
+// PropertyList returns the card property list.
+//
+func (card *Card) PropertyList() (PropertyList, error) {
+	return card.Object.PropertyList("PropertyList")
+}
+
+// CardProfile is a typed access to a pulseaudio card profile.
+// See Client.CardProfile for the properties list.
+//
+type CardProfile struct {
+	*Object
+}
+
+// Name returns the profile name.
+//
+func (profile *CardProfile) Name() (string, error) {
+	return profile.String("Name")
+}
+
+// Description returns the profile human readable description.
+//
+func (profile *CardProfile) Description() (string, error) {
+	return profile.String("Description")
+}
@@ -0,0 +1,51 @@
+// Command pulse_exporter exposes the pulseaudio server state as Prometheus
+// metrics.
+//
+// Usage:
+//   pulse_exporter [-listen :9724] [-path /metrics]
+//
+// See the exporter package for the metrics list.
+// The pulseaudio Dbus module must be loaded, see the pulseaudio package doc.
+//
+package main
+
+import (
+	"github.com/sqp/pulseaudio"
+	"github.com/sqp/pulseaudio/exporter"
+
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+var (
+	listen = flag.String("listen", ":9724", "address to listen on")
+	path   = flag.String("path", "/metrics", "path of the metrics endpoint")
+)
+
+func main() {
+	flag.Parse()
+
+	pulse, e := pulseaudio.New()
+	if e != nil {
+		fatal("connect to the pulse service:", e)
+	}
+	defer pulse.Close()
+
+	exp, e := exporter.New(pulse)
+	if e != nil {
+		fatal("listen events:", e)
+	}
+	defer exp.Close()
+	go pulse.Listen()
+	defer pulse.StopListening()
+
+	http.Handle(*path, exp)
+	fatal("serve:", http.ListenAndServe(*listen, nil))
+}
+
+func fatal(msg string, e error) {
+	fmt.Fprintln(os.Stderr, "pulse_exporter:", msg, e)
+	os.Exit(1)
+}
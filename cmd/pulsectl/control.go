@@ -0,0 +1,159 @@
+package main
+
+import (
+	"github.com/godbus/dbus"
+
+	"fmt"
+	"strings"
+)
+
+// Object kinds with volume and mute controls.
+var controlKinds = []string{kindSink, kindSource, kindPlayback, kindRecord}
+
+// setVolume sets the volume of a device or stream.
+//
+func (c *ctl) setVolume(args []string) error {
+	kind, path, e := c.resolveArgs(args, controlKinds...)
+	if e != nil {
+		return e
+	}
+	if len(args) != 3 {
+		return fmt.Errorf("missing volume")
+	}
+
+	obj := c.object(kind, path)
+	current, e := obj.ListUint32("Volume")
+	if e != nil {
+		return e
+	}
+	vols, e := parseVolume(args[2], current)
+	if e != nil {
+		return e
+	}
+	return obj.Set("Volume", vols)
+}
+
+// Mute actions.
+const (
+	muteOn = iota
+	muteOff
+	muteToggle
+)
+
+// setMute changes the mute state of a device or stream.
+//
+func (c *ctl) setMute(args []string, action int) error {
+	kind, path, e := c.resolveArgs(args, controlKinds...)
+	if e != nil {
+		return e
+	}
+	obj := c.object(kind, path)
+
+	mute := action == muteOn
+	if action == muteToggle {
+		current, e := obj.Bool("Mute")
+		if e != nil {
+			return e
+		}
+		mute = !current
+	}
+	return obj.Set("Mute", mute)
+}
+
+// moveStream moves a stream to another device.
+//
+func (c *ctl) moveStream(args []string) error {
+	kind, path, e := c.resolveArgs(args, kindPlayback, kindRecord)
+	if e != nil {
+		return e
+	}
+	if len(args) != 3 {
+		return fmt.Errorf("missing device")
+	}
+
+	devkind := kindSink
+	if kind == kindRecord {
+		devkind = kindSource
+	}
+	dev, e := c.resolve(devkind, args[2])
+	if e != nil {
+		return e
+	}
+	return c.TypedStream(path).Move(dev)
+}
+
+// setDefault sets the fallback sink or source.
+//
+func (c *ctl) setDefault(args []string) error {
+	kind, path, e := c.resolveArgs(args, kindSink, kindSource)
+	if e != nil {
+		return e
+	}
+	prop := "FallbackSink"
+	if kind == kindSource {
+		prop = "FallbackSource"
+	}
+	return c.Core().Set(prop, path)
+}
+
+// setProfile sets the active profile of a card.
+//
+func (c *ctl) setProfile(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("want CARD PROFILE")
+	}
+	path, e := c.resolve(kindCard, args[0])
+	if e != nil {
+		return e
+	}
+	card := c.Card(path)
+
+	profile := dbus.ObjectPath(args[1])
+	if !strings.HasPrefix(args[1], "/") {
+		found, e := card.ProfileByName(args[1])
+		if e != nil {
+			return e
+		}
+		profile = found.Path()
+	}
+	return card.SetActiveProfile(profile)
+}
+
+// loadModule loads a module with KEY=VALUE arguments.
+//
+func (c *ctl) loadModule(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing module name")
+	}
+	modargs := make(map[string]string)
+	for _, arg := range args[1:] {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid argument %q, want KEY=VALUE", arg)
+		}
+		modargs[kv[0]] = kv[1]
+	}
+
+	mod, e := c.LoadModule(args[0], modargs)
+	if e != nil {
+		return e
+	}
+	index, e := mod.Index()
+	if e != nil {
+		return e
+	}
+	return printValues([]string{"index", "path"}, row{"index": index, "path": string(mod.Path())})
+}
+
+// unloadModule unloads a module.
+//
+func (c *ctl) unloadModule(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("want module ID")
+	}
+	path, e := c.resolve(kindModule, args[0])
+	if e != nil {
+		return e
+	}
+	return c.Module(path).Unload()
+}
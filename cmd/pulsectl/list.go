@@ -0,0 +1,181 @@
+package main
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"fmt"
+)
+
+// info shows the server informations.
+//
+func (c *ctl) info(args []string) error {
+	core := c.Core()
+	r := row{}
+	keys := []string{
+		"Name", "Version", "Hostname", "Username",
+		"InterfaceRevision", "FallbackSink", "FallbackSource", "Extensions",
+	}
+	for _, key := range keys {
+		var val interface{}
+		if core.Get(key, &val) == nil { // Fallback devices may not exist.
+			r[key] = val
+		}
+	}
+	return printValues(keys, r)
+}
+
+// list lists objects of the given type.
+//
+func (c *ctl) list(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("want one of sinks, sources, streams, cards, modules, clients")
+	}
+
+	switch args[0] {
+	case "sinks":
+		return c.listDevices(kindSink)
+
+	case "sources":
+		return c.listDevices(kindSource)
+
+	case "streams":
+		return c.listStreams()
+
+	case "cards":
+		return c.listCards()
+
+	case "modules":
+		return c.listModules()
+
+	case "clients":
+		return c.listClients()
+	}
+	return fmt.Errorf("unknown list %q", args[0])
+}
+
+func (c *ctl) listDevices(kind string) error {
+	paths, e := c.paths(kind)
+	if e != nil {
+		return e
+	}
+	var rows []row
+	for _, path := range paths {
+		dev := c.TypedDevice(path)
+		props, _ := dev.PropertyList()
+		state, _ := dev.State()
+		rows = append(rows, row{
+			"index":       optional(dev.Uint32("Index")),
+			"name":        optional(dev.Name()),
+			"description": props.DeviceDescription(),
+			"state":       state.String(),
+			"volume":      optional(dev.ListUint32("Volume")),
+			"mute":        optional(dev.Bool("Mute")),
+			"path":        path,
+		})
+	}
+	return printRows([]string{"index", "name", "state", "volume", "mute", "description"}, rows)
+}
+
+func (c *ctl) listStreams() error {
+	var rows []row
+	for _, kind := range []string{kindPlayback, kindRecord} {
+		paths, e := c.paths(kind)
+		if e != nil {
+			return e
+		}
+		for _, path := range paths {
+			stream := c.TypedStream(path)
+			props, _ := stream.PropertyList()
+			var device interface{}
+			if devpath, e := stream.Device(); e == nil {
+				device = optional(c.TypedDevice(devpath).Name())
+			}
+			rows = append(rows, row{
+				"type":        kind,
+				"index":       optional(stream.Uint32("Index")),
+				"application": props.ApplicationName(),
+				"media":       props.MediaName(),
+				"device":      device,
+				"volume":      optional(stream.ListUint32("Volume")),
+				"mute":        optional(stream.Bool("Mute")), // Not available on record streams.
+				"path":        path,
+			})
+		}
+	}
+	return printRows([]string{"type", "index", "application", "device", "volume", "mute", "media"}, rows)
+}
+
+func (c *ctl) listCards() error {
+	cards, e := c.Cards()
+	if e != nil {
+		return e
+	}
+	var rows []row
+	for _, card := range cards {
+		props, _ := card.PropertyList()
+		var profile interface{}
+		if active, e := card.ActiveProfile(); e == nil {
+			profile = optional(active.Name())
+		}
+		rows = append(rows, row{
+			"index":       optional(card.Uint32("Index")),
+			"name":        optional(card.Name()),
+			"description": props.DeviceDescription(),
+			"profile":     profile,
+			"path":        card.Path(),
+		})
+	}
+	return printRows([]string{"index", "name", "profile", "description"}, rows)
+}
+
+func (c *ctl) listModules() error {
+	paths, e := c.paths(kindModule)
+	if e != nil {
+		return e
+	}
+	var rows []row
+	for _, path := range paths {
+		mod := c.Module(path)
+		rows = append(rows, row{
+			"index":     optional(mod.Index()),
+			"name":      optional(mod.Name()),
+			"arguments": optional(mod.Arguments()),
+			"path":      path,
+		})
+	}
+	return printRows([]string{"index", "name", "arguments"}, rows)
+}
+
+func (c *ctl) listClients() error {
+	clients, e := c.Clients()
+	if e != nil {
+		return e
+	}
+	var rows []row
+	for _, client := range clients {
+		props, _ := client.PropertyList()
+		pid, _ := props.Lookup(pulseaudio.PropApplicationProcessID)
+		rows = append(rows, row{
+			"index":       optional(client.Index()),
+			"application": props.ApplicationName(),
+			"pid":         pid,
+			"driver":      optional(client.Driver()),
+			"path":        client.Path(),
+		})
+	}
+	return printRows([]string{"index", "application", "pid", "driver"}, rows)
+}
+
+// optional returns the value of an optional property, or nil if it failed.
+//
+func optional(val interface{}, e error) interface{} {
+	if e != nil {
+		return nil
+	}
+	if path, ok := val.(dbus.ObjectPath); ok {
+		return string(path)
+	}
+	return val
+}
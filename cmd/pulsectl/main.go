@@ -0,0 +1,130 @@
+// Command pulsectl controls a pulseaudio server through its Dbus interface.
+//
+// Usage:
+//   pulsectl [-json] command [arguments]
+//
+// Commands:
+//   info                                   Show server informations.
+//   list sinks|sources|streams|cards|modules|clients
+//                                          List objects.
+//   set-volume TYPE ID VOLUME              Set the volume of a device or stream.
+//   mute|unmute|toggle TYPE ID             Change the mute state of a device or stream.
+//   move-stream TYPE ID DEVICE             Move a stream to another device.
+//   set-default sink|source ID             Set the fallback device.
+//   set-profile CARD PROFILE               Set the active profile of a card.
+//   load-module NAME [KEY=VALUE...]        Load a module.
+//   unload-module ID                       Unload a module.
+//   monitor                                Print events as they arrive.
+//
+// TYPE is one of sink, source, playback or record.
+// ID can be an object path, an index or a name. Streams are named by their
+// application or media name, clients by their application name.
+// VOLUME can be a percentage (50%), a relative percentage (+5%, -5%) or a raw
+// value (65536 is 100%).
+//
+// The pulseaudio Dbus module must be loaded, see the pulseaudio package doc.
+//
+package main
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+var jsonOutput = flag.Bool("json", false, "use JSON output")
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	e := run(flag.Args())
+	switch {
+	case e == errUsage:
+		usage()
+		os.Exit(2)
+
+	case e != nil:
+		fmt.Fprintln(os.Stderr, "pulsectl:", e)
+		os.Exit(1)
+	}
+}
+
+// errUsage is returned by run when the command line is invalid.
+var errUsage = errors.New("usage")
+
+// run executes a command on a new pulseaudio client.
+//
+func run(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	cmd := args[0]
+	call, ok := commands[cmd]
+	if !ok {
+		fmt.Fprintln(os.Stderr, "unknown command:", cmd)
+		return errUsage
+	}
+
+	pulse, e := pulseaudio.New()
+	if e != nil {
+		return fmt.Errorf("connect to the pulse service: %v", e)
+	}
+	defer pulse.Close()
+
+	e = call(&ctl{pulse}, args[1:])
+	if e != nil {
+		return fmt.Errorf("%s: %v", cmd, e)
+	}
+	return nil
+}
+
+// ctl runs commands on a pulseaudio client.
+//
+type ctl struct {
+	*pulseaudio.Client
+}
+
+// commands lists the available commands by name.
+//
+var commands = map[string]func(*ctl, []string) error{
+	"info":          (*ctl).info,
+	"list":          (*ctl).list,
+	"set-volume":    (*ctl).setVolume,
+	"mute":          func(c *ctl, args []string) error { return c.setMute(args, muteOn) },
+	"unmute":        func(c *ctl, args []string) error { return c.setMute(args, muteOff) },
+	"toggle":        func(c *ctl, args []string) error { return c.setMute(args, muteToggle) },
+	"move-stream":   (*ctl).moveStream,
+	"set-default":   (*ctl).setDefault,
+	"set-profile":   (*ctl).setProfile,
+	"load-module":   (*ctl).loadModule,
+	"unload-module": (*ctl).unloadModule,
+	"monitor":       (*ctl).monitor,
+}
+
+func usage() {
+	fmt.Fprint(os.Stderr, `usage: pulsectl [-json] command [arguments]
+
+commands:
+  info                                   show server informations
+  list sinks|sources|streams|cards|modules|clients
+  set-volume TYPE ID VOLUME              VOLUME: 50%, +5%, -5% or raw (65536 is 100%)
+  mute|unmute|toggle TYPE ID
+  move-stream TYPE ID DEVICE
+  set-default sink|source ID
+  set-profile CARD PROFILE
+  load-module NAME [KEY=VALUE...]
+  unload-module ID
+  monitor                                print events as they arrive
+
+TYPE is one of sink, source, playback or record.
+ID can be an object path, an index or a name (application or media name for
+streams).
+
+options:
+`)
+	flag.PrintDefaults()
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// monitor prints pulseaudio events until interrupted.
+//
+func (c *ctl) monitor(args []string) error {
+	mon := &monitor{}
+	if errs := c.Register(mon); len(errs) > 0 {
+		return errs[0]
+	}
+	defer c.Unregister(mon)
+
+	c.SetOnUnknownSignal(func(s *dbus.Signal) { mon.print(string(s.Name), s.Path, s.Body) })
+	go c.Listen()
+	defer c.StopListening()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	<-stop
+	return nil
+}
+
+// monitor is a pulseaudio client that prints all events.
+//
+type monitor struct{}
+
+// event is a printed event.
+type event struct {
+	Time  time.Time       `json:"time"`
+	Event string          `json:"event"`
+	Path  dbus.ObjectPath `json:"path,omitempty"`
+	Value interface{}     `json:"value,omitempty"`
+}
+
+func (mon *monitor) print(name string, path dbus.ObjectPath, value interface{}) {
+	ev := event{Time: time.Now(), Event: name, Path: path, Value: value}
+	if *jsonOutput {
+		json.NewEncoder(os.Stdout).Encode(ev)
+		return
+	}
+	if vols, ok := value.([]uint32); ok {
+		value = formatVolume(vols)
+	}
+	if value == nil {
+		value = ""
+	}
+	fmt.Println(ev.Time.Format("15:04:05.000"), name, path, value)
+}
+
+func (mon *monitor) FallbackSinkUpdatedPath(path dbus.ObjectPath) {
+	mon.print("FallbackSinkUpdated", path, nil)
+}
+
+func (mon *monitor) FallbackSinkUnset() {
+	mon.print("FallbackSinkUnset", "", nil)
+}
+
+func (mon *monitor) NewSinkPath(path dbus.ObjectPath) {
+	mon.print("NewSink", path, nil)
+}
+
+func (mon *monitor) SinkRemovedPath(path dbus.ObjectPath) {
+	mon.print("SinkRemoved", path, nil)
+}
+
+func (mon *monitor) NewPlaybackStream(path dbus.ObjectPath) {
+	mon.print("NewPlaybackStream", path, nil)
+}
+
+func (mon *monitor) PlaybackStreamRemoved(path dbus.ObjectPath) {
+	mon.print("PlaybackStreamRemoved", path, nil)
+}
+
+func (mon *monitor) NewSample(path dbus.ObjectPath) {
+	mon.print("NewSample", path, nil)
+}
+
+func (mon *monitor) SampleRemoved(path dbus.ObjectPath) {
+	mon.print("SampleRemoved", path, nil)
+}
+
+func (mon *monitor) DeviceVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	mon.print("DeviceVolumeUpdated", path, values)
+}
+
+func (mon *monitor) DeviceMuteUpdated(path dbus.ObjectPath, mute bool) {
+	mon.print("DeviceMuteUpdated", path, mute)
+}
+
+func (mon *monitor) DeviceActivePortUpdated(path, port dbus.ObjectPath) {
+	mon.print("DeviceActivePortUpdated", path, port)
+}
+
+func (mon *monitor) DeviceStateUpdated(path dbus.ObjectPath, state pulseaudio.DeviceState) {
+	mon.print("DeviceStateUpdated", path, state.String())
+}
+
+func (mon *monitor) StreamVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	mon.print("StreamVolumeUpdated", path, values)
+}
+
+func (mon *monitor) StreamMuteUpdated(path dbus.ObjectPath, mute bool) {
+	mon.print("StreamMuteUpdated", path, mute)
+}
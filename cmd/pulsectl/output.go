@@ -0,0 +1,96 @@
+package main
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// row is an output line, indexed by column name.
+type row map[string]interface{}
+
+// printRows prints rows as a table with the given columns, or as a JSON list.
+//
+func printRows(cols []string, rows []row) error {
+	if *jsonOutput {
+		if rows == nil {
+			rows = []row{}
+		}
+		return printJSON(rows)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(cols, "\t")))
+	for _, r := range rows {
+		cells := make([]string, len(cols))
+		for i, col := range cols {
+			cells[i] = formatCell(col, r[col])
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// printValues prints a single object as key: value lines, or as JSON.
+//
+func printValues(keys []string, r row) error {
+	if *jsonOutput {
+		return printJSON(r)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s:\t%s\n", key, formatCell(key, r[key]))
+	}
+	return w.Flush()
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// formatCell formats a value for the table output.
+//
+func formatCell(col string, v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "-"
+
+	case []uint32:
+		if col == "volume" {
+			return formatVolume(val)
+		}
+
+	case []string:
+		return strings.Join(val, ",")
+
+	case map[string]string:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for i, k := range keys {
+			keys[i] = k + "=" + val[k]
+		}
+		return strings.Join(keys, " ")
+	}
+	return fmt.Sprint(v)
+}
+
+// formatVolume formats a list of channel volumes as percentages.
+//
+func formatVolume(vols []uint32) string {
+	strs := make([]string, len(vols))
+	for i, vol := range vols {
+		strs[i] = strconv.Itoa(int((uint64(vol)*100+uint64(pulseaudio.VolumeNorm)/2)/uint64(pulseaudio.VolumeNorm))) + "%"
+	}
+	return strings.Join(strs, " ")
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Object kinds that can be referenced on the command line.
+const (
+	kindSink     = "sink"
+	kindSource   = "source"
+	kindPlayback = "playback"
+	kindRecord   = "record"
+	kindCard     = "card"
+	kindModule   = "module"
+	kindClient   = "client"
+)
+
+// coreLists defines the Core property listing the objects of each kind.
+var coreLists = map[string]string{
+	kindSink:     "Sinks",
+	kindSource:   "Sources",
+	kindPlayback: "PlaybackStreams",
+	kindRecord:   "RecordStreams",
+	kindCard:     "Cards",
+	kindModule:   "Modules",
+	kindClient:   "Clients",
+}
+
+// coreGetByName defines the Core method to find an object of a kind by name.
+var coreGetByName = map[string]string{
+	kindSink:   "GetSinkByName",
+	kindSource: "GetSourceByName",
+	kindCard:   "GetCardByName",
+}
+
+// object returns the generic object of the given kind.
+//
+func (c *ctl) object(kind string, path dbus.ObjectPath) *pulseaudio.Object {
+	switch kind {
+	case kindSink, kindSource:
+		return c.Device(path)
+	case kindPlayback, kindRecord:
+		return c.Stream(path)
+	case kindCard:
+		return c.Card(path).Object
+	case kindModule:
+		return c.Module(path).Object
+	}
+	return c.Client.Client(path)
+}
+
+// paths returns the paths of all objects of the given kind.
+//
+func (c *ctl) paths(kind string) ([]dbus.ObjectPath, error) {
+	prop, ok := coreLists[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", kind)
+	}
+	return c.Core().ListPath(prop)
+}
+
+// resolve finds the path of an object of the given kind referenced by its
+// path, index or name. A name matching many objects is an error.
+//
+func (c *ctl) resolve(kind, id string) (dbus.ObjectPath, error) {
+	if strings.HasPrefix(id, "/") {
+		return dbus.ObjectPath(id), nil
+	}
+
+	if method, ok := coreGetByName[kind]; ok {
+		if _, e := strconv.ParseUint(id, 10, 32); e != nil {
+			var path dbus.ObjectPath
+			e = c.Core().Call(pulseaudio.DbusInterface+"."+method, 0, id).Store(&path)
+			return path, e
+		}
+	}
+
+	paths, e := c.paths(kind)
+	if e != nil {
+		return "", e
+	}
+	index, isIndex := strconv.ParseUint(id, 10, 32)
+	var found []dbus.ObjectPath
+	for _, path := range paths {
+		obj := c.object(kind, path)
+		if isIndex == nil {
+			if idx, e := obj.Uint32("Index"); e == nil && uint64(idx) == index {
+				return path, nil
+			}
+			continue
+		}
+		if hasName(kind, obj, id) {
+			found = append(found, path)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("%s %q not found", kind, id)
+	case 1:
+		return found[0], nil
+	}
+	return "", fmt.Errorf("%s %q matches %d objects, use the index", kind, id, len(found))
+}
+
+// hasName returns whether the object has the given name. Streams are named by
+// their application or media name, clients by their application name.
+//
+func hasName(kind string, obj *pulseaudio.Object, name string) bool {
+	if kind == kindModule {
+		found, e := obj.String("Name")
+		return e == nil && found == name
+	}
+	props, e := obj.PropertyList("PropertyList")
+	if e != nil {
+		return false
+	}
+	return props.ApplicationName() == name || (kind != kindClient && props.MediaName() == name)
+}
+
+// resolveArgs resolves a TYPE ID pair of arguments.
+//
+func (c *ctl) resolveArgs(args []string, kinds ...string) (kind string, path dbus.ObjectPath, e error) {
+	if len(args) < 2 {
+		return "", "", fmt.Errorf("missing arguments, want %s ID", strings.Join(kinds, "|"))
+	}
+	kind = args[0]
+	if !contains(kinds, kind) {
+		return "", "", fmt.Errorf("invalid type %q, want %s", kind, strings.Join(kinds, "|"))
+	}
+	path, e = c.resolve(kind, args[1])
+	return kind, path, e
+}
+
+func contains(list []string, str string) bool {
+	for _, test := range list {
+		if test == str {
+			return true
+		}
+	}
+	return false
+}
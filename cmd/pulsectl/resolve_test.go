@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"testing"
+)
+
+func TestResolveName(t *testing.T) {
+	props := map[dbus.ObjectPath]map[string]interface{}{
+		pulseaudio.DbusPath: {
+			"PlaybackStreams": []dbus.ObjectPath{"/stream0", "/stream1", "/stream2"},
+			"Clients":         []dbus.ObjectPath{"/client0", "/client1"},
+		},
+		"/stream0": {"Index": uint32(4), "PropertyList": map[string][]byte{"application.name": []byte("mpv\x00"), "media.name": []byte("song\x00")}},
+		"/stream1": {"Index": uint32(5), "PropertyList": map[string][]byte{"application.name": []byte("firefox\x00"), "media.name": []byte("video\x00")}},
+		"/stream2": {"Index": uint32(6), "PropertyList": map[string][]byte{"application.name": []byte("firefox\x00"), "media.name": []byte("call\x00")}},
+		"/client0": {"Index": uint32(1), "PropertyList": map[string][]byte{"application.name": []byte("mpv\x00")}},
+		"/client1": {"Index": uint32(2), "PropertyList": map[string][]byte{"application.name": []byte("firefox\x00"), "media.name": []byte("song\x00")}},
+	}
+	pulse := pulseaudio.NewReplayClient()
+	pulse.SetReplayHandler(func(path dbus.ObjectPath, method string, args ...interface{}) ([]interface{}, error) {
+		if method != "org.freedesktop.DBus.Properties.Get" {
+			return nil, pulseaudio.ErrNoServer
+		}
+		value, ok := props[path][args[1].(string)]
+		if !ok {
+			return nil, dbus.Error{Name: "org.PulseAudio.Core1.NoSuchPropertyError", Body: []interface{}{"no property"}}
+		}
+		return []interface{}{dbus.MakeVariant(value)}, nil
+	})
+	c := &ctl{pulse}
+
+	for _, test := range []struct {
+		kind, id string
+		want     dbus.ObjectPath
+	}{
+		{kindPlayback, "/stream1", "/stream1"},
+		{kindPlayback, "5", "/stream1"},
+		{kindPlayback, "mpv", "/stream0"},
+		{kindPlayback, "call", "/stream2"},
+		{kindClient, "firefox", "/client1"},
+		{kindClient, "2", "/client1"},
+	} {
+		path, e := c.resolve(test.kind, test.id)
+		if e != nil || path != test.want {
+			t.Errorf("resolve(%s, %s) = %s, %v, want %s", test.kind, test.id, path, e, test.want)
+		}
+	}
+
+	for _, test := range []struct{ kind, id string }{
+		{kindPlayback, "firefox"}, // Ambiguous.
+		{kindPlayback, "vlc"},
+		{kindPlayback, "7"},
+		{kindClient, "song"}, // Clients have no media name.
+	} {
+		path, e := c.resolve(test.kind, test.id)
+		if e == nil {
+			t.Errorf("resolve(%s, %s) = %s, want an error", test.kind, test.id, path)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseVolume returns the new channels volumes from a command line value and
+// the current channels volumes.
+//
+// Accepted values:
+//   50%       Percentage, applied to all channels.
+//   +5% -5%   Relative percentage, added to each channel.
+//   65536     Raw volume, applied to all channels.
+//
+func parseVolume(arg string, current []uint32) ([]uint32, error) {
+	if len(current) == 0 {
+		current = []uint32{0}
+	}
+
+	relative := strings.HasPrefix(arg, "+") || strings.HasPrefix(arg, "-")
+	str := strings.TrimPrefix(arg, "+")
+
+	var delta int64
+	if strings.HasSuffix(str, "%") {
+		percent, e := strconv.ParseFloat(strings.TrimSuffix(str, "%"), 64)
+		if e != nil {
+			return nil, fmt.Errorf("invalid volume %q", arg)
+		}
+		delta = int64(percent * float64(pulseaudio.VolumeNorm) / 100)
+
+	} else {
+		if relative {
+			return nil, fmt.Errorf("invalid volume %q, relative values must be percentages", arg)
+		}
+		raw, e := strconv.ParseUint(str, 10, 32)
+		if e != nil {
+			return nil, fmt.Errorf("invalid volume %q", arg)
+		}
+		delta = int64(raw)
+	}
+
+	vols := make([]uint32, len(current))
+	for i, cur := range current {
+		vol := delta
+		if relative {
+			vol += int64(cur)
+		}
+		switch {
+		case vol < 0:
+			vol = 0
+		case vol > 0xFFFFFFFF:
+			return nil, fmt.Errorf("invalid volume %q, too high", arg)
+		}
+		vols[i] = uint32(vol)
+	}
+	return vols, nil
+}
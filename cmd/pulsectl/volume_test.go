@@ -0,0 +1,28 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVolume(t *testing.T) {
+	current := []uint32{32768, 65536}
+	for arg, want := range map[string][]uint32{
+		"50%":   {32768, 32768},
+		"100%":  {65536, 65536},
+		"+10%":  {39321, 72089},
+		"-60%":  {0, 26215},
+		"65536": {65536, 65536},
+	} {
+		got, e := parseVolume(arg, current)
+		if e != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("parse volume %q: got %v, %v, want %v", arg, got, e, want)
+		}
+	}
+
+	for _, arg := range []string{"", "abc", "+5", "-1", "x%", "99999999999"} {
+		if _, e := parseVolume(arg, current); e == nil {
+			t.Errorf("parse volume %q: expected an error", arg)
+		}
+	}
+}
@@ -0,0 +1,659 @@
+package main
+
+import (
+	"github.com/godbus/dbus/introspect"
+
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Interfaces names.
+const coreInterface = "org.PulseAudio.Core1"
+
+// Go type names of the interfaces not named after the last part of their Dbus
+// name.
+var typeNames = map[string]string{
+	"org.PulseAudio.Ext.Equalizing1.Manager": "EqualizerManager",
+}
+
+// Prefixes of the signals payload types, when it's not the interface type
+// name. Core signals have no prefix.
+var eventPrefixes = map[string]string{
+	coreInterface:                            "",
+	"org.PulseAudio.Ext.Equalizing1.Manager": "Equalizer",
+}
+
+// Interfaces of objects with a fixed path. Their signals payloads have no path
+// field for the emitter, and a single object argument (the object added or
+// removed) is named Path.
+var singletons = map[string]bool{
+	coreInterface:                            true,
+	"org.PulseAudio.Ext.StreamRestore1":      true,
+	"org.PulseAudio.Ext.Equalizing1.Manager": true,
+}
+
+// Events tables, indexed by interface name prefix.
+var eventTables = map[string]string{
+	coreInterface:                       "PulseEvents",
+	"org.PulseAudio.Ext.StreamRestore1": "StreamRestoreEvents",
+	"org.PulseAudio.Ext.Equalizing1":    "EqualizerEvents",
+}
+
+// Description of the events tables signals, when it's not the table name.
+var tableDocs = map[string]string{
+	"PulseEvents":         "core",
+	"StreamRestoreEvents": "stream restore",
+}
+
+// Signals of singletons whose On... method receives the signal path, as in the
+// first versions of the package. The object argument is given to the method
+// with a Path suffix.
+var emitterMethods = map[string]bool{
+	coreInterface + ".FallbackSinkUpdated": true,
+	coreInterface + ".NewSink":             true,
+	coreInterface + ".SinkRemoved":         true,
+}
+
+// Go types of signals arguments with a dedicated type, indexed by
+// interface.signal.argument.
+var argTypes = map[string]string{
+	coreInterface + ".Device.StateUpdated.state":             "pulseaudio.DeviceState",
+	coreInterface + ".DevicePort.AvailableChanged.available": "pulseaudio.PortAvailability",
+}
+
+// Dbus basic types, with their Go equivalent.
+var basicTypes = map[byte]string{
+	'b': "bool",
+	'y': "byte",
+	'n': "int16",
+	'q': "uint16",
+	'i': "int32",
+	'u': "uint32",
+	'x': "int64",
+	't': "uint64",
+	'd': "float64",
+	's': "string",
+	'o': "dbus.ObjectPath",
+	'g': "dbus.Signature",
+	'v': "dbus.Variant",
+}
+
+// Dbus types with a dedicated type in the pulseaudio package.
+var mappedTypes = map[string]string{
+	"a{say}": "pulseaudio.PropertyList",
+	"a(uu)":  "[]pulseaudio.ChannelVolume",
+	"(adab)": "pulseaudio.LadspaParameters",
+}
+
+//
+//-------------------------------------------------------------------[ INPUT ]--
+
+// readFiles parses introspection XML files. Directories are expanded to the
+// XML files they contain.
+//
+func readFiles(args []string) ([]introspect.Interface, error) {
+	var files []string
+	for _, arg := range args {
+		st, e := os.Stat(arg)
+		if e != nil {
+			return nil, e
+		}
+		if !st.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		list, e := filepath.Glob(filepath.Join(arg, "*.xml"))
+		if e != nil {
+			return nil, e
+		}
+		sort.Strings(list)
+		files = append(files, list...)
+	}
+
+	var nodes []*introspect.Node
+	for _, file := range files {
+		data, e := os.ReadFile(file)
+		if e != nil {
+			return nil, e
+		}
+		node := &introspect.Node{}
+		e = xml.Unmarshal(data, node)
+		if e != nil {
+			return nil, fmt.Errorf("%s: %v", file, e)
+		}
+		nodes = append(nodes, node)
+	}
+	return mergeNodes(nodes...)
+}
+
+// mergeNodes returns the pulseaudio interfaces of the nodes, without the
+// standard Dbus ones.
+//
+// An interface declared without members references its definition in another
+// node, so shared interfaces like Device are written once. An interface defined
+// many times must have the same definition everywhere.
+//
+func mergeNodes(nodes ...*introspect.Node) (list []introspect.Interface, e error) {
+	index := make(map[string]int) // Position in list, by interface name.
+	var refs []string
+	for _, node := range nodes {
+		for _, iface := range node.Interfaces {
+			switch {
+			case strings.HasPrefix(iface.Name, "org.freedesktop."):
+
+			case isReference(iface):
+				refs = append(refs, iface.Name)
+
+			default:
+				i, ok := index[iface.Name]
+				if !ok {
+					index[iface.Name] = len(list)
+					list = append(list, iface)
+				} else if !reflect.DeepEqual(list[i], iface) {
+					return nil, fmt.Errorf("interface %s: different definitions", iface.Name)
+				}
+			}
+		}
+	}
+	for _, name := range refs {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("interface %s: referenced but not defined", name)
+		}
+	}
+	return list, nil
+}
+
+// isReference returns whether the interface is declared without members.
+//
+func isReference(iface introspect.Interface) bool {
+	return len(iface.Methods) == 0 && len(iface.Properties) == 0 && len(iface.Signals) == 0
+}
+
+//
+//-------------------------------------------------------------------[ NAMES ]--
+
+// typeName returns the Go type name of an interface: the last part of its name
+// without version, unless set in typeNames.
+//
+func typeName(iface introspect.Interface) string {
+	if name, ok := typeNames[iface.Name]; ok {
+		return name
+	}
+	name := iface.Name[strings.LastIndex(iface.Name, ".")+1:]
+	return strings.TrimRightFunc(name, unicode.IsDigit)
+}
+
+// eventName returns the signal name as referenced in the pulseaudio Hooker:
+// core signals without the interface prefix, extensions with their full name.
+//
+func eventName(iface, signal string) string {
+	name := iface + "." + signal
+	return strings.TrimPrefix(name, coreInterface+".")
+}
+
+// payloadName returns the Go type name of a signal payload. It's also the name
+// of its On... interface method.
+//
+func payloadName(iface introspect.Interface, signal string) string {
+	if prefix, ok := eventPrefixes[iface.Name]; ok {
+		return prefix + signal
+	}
+	return typeName(iface) + signal
+}
+
+// tableName returns the events table of an interface.
+//
+func tableName(iface introspect.Interface) string {
+	match := ""
+	for prefix := range eventTables {
+		if (iface.Name == prefix || strings.HasPrefix(iface.Name, prefix+".")) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	if match == "" {
+		return typeName(iface) + "Events"
+	}
+	return eventTables[match]
+}
+
+// camelCase converts a Dbus argument name to a Go identifier.
+//
+func camelCase(name string, exported bool) string {
+	var buf strings.Builder
+	for i, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		if i > 0 || exported {
+			part = strings.ToUpper(part[:1]) + part[1:]
+		}
+		buf.WriteString(part)
+	}
+	return buf.String()
+}
+
+// argNames returns the Go names of arguments, unique and safe to use as
+// parameters.
+//
+func argNames(args []introspect.Arg, exported bool, reserved ...string) []string {
+	used := make(map[string]bool)
+	for _, name := range reserved {
+		used[name] = true
+	}
+	names := make([]string, len(args))
+	for i, arg := range args {
+		name := camelCase(arg.Name, exported)
+		if name == "" || used[name] || token.IsKeyword(name) {
+			name = fmt.Sprintf("arg%d", i)
+			if exported {
+				name = fmt.Sprintf("Arg%d", i)
+			}
+		}
+		used[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+//
+//-------------------------------------------------------------------[ TYPES ]--
+
+// goType returns the Go type of a Dbus signature.
+//
+func goType(sig string) (string, error) {
+	if typ, ok := mappedTypes[sig]; ok {
+		return typ, nil
+	}
+	switch {
+	case len(sig) == 1 && basicTypes[sig[0]] != "":
+		return basicTypes[sig[0]], nil
+
+	case strings.HasPrefix(sig, "a{") && strings.HasSuffix(sig, "}") && len(sig) > 4 && basicTypes[sig[2]] != "":
+		val, e := goType(sig[3 : len(sig)-1])
+		if e != nil {
+			return "", e
+		}
+		return "map[" + basicTypes[sig[2]] + "]" + val, nil
+
+	case strings.HasPrefix(sig, "a"):
+		val, e := goType(sig[1:])
+		if e != nil {
+			return "", e
+		}
+		return "[]" + val, nil
+
+	case strings.HasPrefix(sig, "(") && strings.HasSuffix(sig, ")"):
+		return "[]interface{}", nil // Dbus structs are received as lists.
+	}
+	return "", fmt.Errorf("unsupported Dbus type %q", sig)
+}
+
+// splitArgs splits method arguments by direction.
+//
+func splitArgs(args []introspect.Arg) (in, out []introspect.Arg) {
+	for _, arg := range args {
+		if arg.Direction == "out" {
+			out = append(out, arg)
+		} else {
+			in = append(in, arg)
+		}
+	}
+	return in, out
+}
+
+//
+//-----------------------------------------------------------------[ EMITTER ]--
+
+// generator writes the Go bindings of a list of interfaces.
+//
+type generator struct {
+	buf    bytes.Buffer
+	qual   string              // Qualifier of the pulseaudio package types, empty inside it.
+	tables []string            // Events tables names, in declaration order.
+	events map[string][]string // Events tables entries, indexed by table.
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// section writes a section banner.
+//
+func (g *generator) section(name string) {
+	title := "[ " + strings.ToUpper(name) + " ]--"
+	g.printf("\n//\n//%s%s\n", strings.Repeat("-", 78-len(title)), title)
+}
+
+// goType returns the Go type of a Dbus signature, qualified for the package.
+//
+func (g *generator) goType(sig string) (string, error) {
+	typ, e := goType(sig)
+	return strings.Replace(typ, "pulseaudio.", g.qual, -1), e
+}
+
+// format returns the formatted Go source.
+//
+func (g *generator) format() ([]byte, error) {
+	src, e := format.Source(g.buf.Bytes())
+	if e != nil {
+		return nil, fmt.Errorf("format: %v", e)
+	}
+	return src, nil
+}
+
+// generate returns the Go source of the objects bindings: property getters
+// and setters, and methods calls.
+//
+func generate(pkg string, ifaces []introspect.Interface) ([]byte, error) {
+	g := &generator{qual: "pulseaudio."}
+	g.printf("// Code generated by pulsegen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", pkg)
+	g.printf("import (\n\t\"github.com/godbus/dbus\"\n\n\t\"github.com/sqp/pulseaudio\"\n)\n")
+
+	g.printf("\n// Dbus interfaces names.\nconst (\n")
+	for _, iface := range ifaces {
+		g.printf("\t%sInterface = %q\n", typeName(iface), iface.Name)
+	}
+	g.printf(")\n")
+
+	for _, iface := range ifaces {
+		e := g.object(iface)
+		if e != nil {
+			return nil, fmt.Errorf("%s: %v", iface.Name, e)
+		}
+	}
+	return g.format()
+}
+
+// generateEvents returns the Go source of the signals, for the pulseaudio
+// package: payload types, On... interfaces and events tables.
+//
+func generateEvents(pkg string, ifaces []introspect.Interface) ([]byte, error) {
+	g := &generator{events: make(map[string][]string)}
+	if pkg != "pulseaudio" {
+		g.qual = "pulseaudio."
+	}
+	g.printf("// Code generated by pulsegen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", pkg)
+	g.printf("import (\n\t\"github.com/godbus/dbus\"\n")
+	if g.qual != "" {
+		g.printf("\n\t\"github.com/sqp/pulseaudio\"\n")
+	}
+	g.printf(")\n")
+
+	for _, iface := range ifaces {
+		if len(iface.Signals) == 0 {
+			continue
+		}
+		g.section(typeName(iface))
+		for _, signal := range iface.Signals {
+			e := g.signal(iface, signal)
+			if e != nil {
+				return nil, fmt.Errorf("%s: signal %s: %v", iface.Name, signal.Name, e)
+			}
+		}
+	}
+
+	g.section("events tables")
+	for _, table := range g.tables {
+		desc, ok := tableDocs[table]
+		if !ok {
+			desc = strings.ToLower(strings.TrimSuffix(table, "Events"))
+		}
+		g.printf("\n// %s declares the %s signals.\n", table, desc)
+		g.printf("// Public so it can be hacked before the client creation.\n//\n")
+		g.printf("var %s = %sEvents{\n", table, g.qual)
+		for _, entry := range g.events[table] {
+			g.printf("%s,\n", entry)
+		}
+		g.printf("}\n")
+	}
+	return g.format()
+}
+
+// object writes the object type, its properties and methods.
+//
+func (g *generator) object(iface introspect.Interface) error {
+	typ := typeName(iface)
+	g.section(typ)
+	g.printf("\n// %s wraps an object with the %s interface.\n", typ, iface.Name)
+	g.printf("type %s struct{ obj *pulseaudio.Object }\n", typ)
+	g.printf("\n// New%s returns the %s object at path.\n", typ, typ)
+	g.printf("func New%s(pulse *pulseaudio.Client, path dbus.ObjectPath) %s {\n", typ, typ)
+	g.printf("\treturn %s{pulse.Object(%sInterface, path)}\n}\n", typ, typ)
+	g.printf("\n// Object returns the Dbus object, to access properties by name.\n")
+	g.printf("func (o %s) Object() *pulseaudio.Object { return o.obj }\n", typ)
+
+	used := map[string]bool{"Object": true}
+	declare := func(name string) error {
+		if used[name] {
+			return fmt.Errorf("duplicate method %s.%s", typ, name)
+		}
+		used[name] = true
+		return nil
+	}
+
+	for _, prop := range iface.Properties {
+		e := g.property(typ, prop, declare)
+		if e != nil {
+			return fmt.Errorf("property %s: %v", prop.Name, e)
+		}
+	}
+	for _, method := range iface.Methods {
+		e := declare(method.Name)
+		if e != nil {
+			return e
+		}
+		e = g.method(typ, method)
+		if e != nil {
+			return fmt.Errorf("method %s: %v", method.Name, e)
+		}
+	}
+	return nil
+}
+
+// property writes the getter, and setter if the property is writable.
+//
+func (g *generator) property(typ string, prop introspect.Property, declare func(string) error) error {
+	valType, e := g.goType(prop.Type)
+	if e != nil {
+		return e
+	}
+	if strings.Contains(prop.Access, "read") {
+		e = declare(prop.Name)
+		if e != nil {
+			return e
+		}
+		g.printf("\n// %s gets the %s property.\n", prop.Name, prop.Name)
+		g.printf("func (o %s) %s() (val %s, e error) {\n", typ, prop.Name, valType)
+		g.printf("\te = o.obj.Get(%q, &val)\n\treturn val, e\n}\n", prop.Name)
+	}
+	if strings.Contains(prop.Access, "write") {
+		e = declare("Set" + prop.Name)
+		if e != nil {
+			return e
+		}
+		g.printf("\n// Set%s sets the %s property.\n", prop.Name, prop.Name)
+		g.printf("func (o %s) Set%s(val %s) error {\n", typ, prop.Name, valType)
+		g.printf("\treturn o.obj.Set(%q, val)\n}\n", prop.Name)
+	}
+	return nil
+}
+
+// method writes the method call with typed arguments and results.
+//
+func (g *generator) method(typ string, method introspect.Method) error {
+	in, out := splitArgs(method.Args)
+	inTypes, e := g.goTypes(in)
+	if e != nil {
+		return e
+	}
+	outTypes, e := g.goTypes(out)
+	if e != nil {
+		return e
+	}
+	inNames := argNames(in, false, "o", "e", "call")
+	outNames := argNames(out, false, append(inNames, "o", "e", "call")...)
+
+	params := make([]string, len(in))
+	for i := range in {
+		params[i] = inNames[i] + " " + inTypes[i]
+	}
+	results := make([]string, len(out), len(out)+1)
+	dests := make([]string, len(out))
+	for i := range out {
+		results[i] = outNames[i] + " " + outTypes[i]
+		dests[i] = ", &" + outNames[i]
+	}
+	results = append(results, "e error")
+
+	callArgs := ""
+	if len(in) > 0 {
+		callArgs = ", " + strings.Join(inNames, ", ")
+	}
+	call := fmt.Sprintf("o.obj.Call(%sInterface+%q, 0%s)", typ, "."+method.Name, callArgs)
+
+	g.printf("\n// %s calls the %s method.\n", method.Name, method.Name)
+	if len(out) == 0 {
+		g.printf("func (o %s) %s(%s) error {\n", typ, method.Name, strings.Join(params, ", "))
+		g.printf("\treturn %s.Err\n}\n", call)
+		return nil
+	}
+	g.printf("func (o %s) %s(%s) (%s) {\n", typ, method.Name, strings.Join(params, ", "), strings.Join(results, ", "))
+	g.printf("\te = storeCall(%s%s)\n", call, strings.Join(dests, ""))
+	g.printf("\treturn %s, e\n}\n", strings.Join(outNames, ", "))
+	return nil
+}
+
+// goTypes returns the Go types of arguments.
+//
+func (g *generator) goTypes(args []introspect.Arg) ([]string, error) {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		typ, e := g.goType(arg.Type)
+		if e != nil {
+			return nil, fmt.Errorf("arg %s: %v", arg.Name, e)
+		}
+		types[i] = typ
+	}
+	return types, nil
+}
+
+// signal writes the payload type, its On... interface and decoder, and adds
+// its definition to the events table of the interface.
+//
+func (g *generator) signal(iface introspect.Interface, signal introspect.Signal) error {
+	name := payloadName(iface, signal.Name)
+	types, e := g.goTypes(signal.Args)
+	if e != nil {
+		return e
+	}
+	for i, arg := range signal.Args {
+		if typ, ok := argTypes[iface.Name+"."+signal.Name+"."+arg.Name]; ok {
+			types[i] = strings.Replace(typ, "pulseaudio.", g.qual, -1)
+		}
+	}
+	fields := argNames(signal.Args, true, "Path", "EventName")
+
+	// The emitter path is the first field, or the object argument of singletons.
+	withPath := !singletons[iface.Name]
+	argPath := !withPath && len(signal.Args) == 1 && signal.Args[0].Type == "o"
+	if argPath {
+		fields[0] = "Path"
+	}
+
+	g.printf("\n// %s is the payload of the %s.%s signal.\n", name, iface.Name, signal.Name)
+	if !withPath && len(signal.Args) == 0 {
+		g.printf("type %s struct{}\n", name)
+	} else {
+		g.printf("type %s struct {\n", name)
+		if withPath {
+			g.printf("\tPath dbus.ObjectPath // Object emitting the signal.\n")
+		}
+		for i := range signal.Args {
+			g.printf("\t%s %s", fields[i], types[i])
+			if argPath {
+				g.printf(" // Sent as signal data, not the emitter.")
+			}
+			g.printf("\n")
+		}
+		g.printf("}\n")
+	}
+	g.printf("\n// EventName returns the signal name of the event.\n")
+	g.printf("func (%s) EventName() string { return %q }\n", name, eventName(iface.Name, signal.Name))
+
+	var params, values, dests []string
+	if withPath {
+		params = append(params, "dbus.ObjectPath")
+		values = append(values, "ev.Path")
+	}
+	for i := range signal.Args {
+		params = append(params, types[i])
+		values = append(values, "ev."+fields[i])
+		dests = append(dests, "&ev."+fields[i])
+	}
+
+	method := name
+	if emitterMethods[iface.Name+"."+signal.Name] && argPath {
+		method += "Path"
+	}
+	g.printf("\n// On%s is an interface to the %s method.\n", method, method)
+	if argPath {
+		g.printf("// The argument is the %s path sent as signal data, not the emitter path.\n",
+			strings.Replace(signal.Args[0].Name, "_", " ", -1))
+	}
+	g.printf("type On%s interface {\n\t%s(%s)\n}\n", method, method, strings.Join(params, ", "))
+
+	g.printf("\nfunc decode%s(m %sMsg) (ev %s, e error) {\n", name, g.qual, name)
+	if withPath {
+		g.printf("\tev.Path = m.P\n")
+	}
+	if len(dests) > 0 {
+		g.printf("\te = %sStoreArgs(m, %s)\n", g.qual, strings.Join(dests, ", "))
+	}
+	g.printf("\treturn ev, e\n}\n")
+
+	table := tableName(iface)
+	if _, ok := g.events[table]; !ok {
+		g.tables = append(g.tables, table)
+	}
+	if method != name {
+		g.emitterSignal(iface, signal, table)
+	}
+	g.events[table] = append(g.events[table], fmt.Sprintf("%sDefineEvent(decode%s, func(o On%s, ev %s) {\no.%s(%s)\n})",
+		g.qual, name, method, name, method, strings.Join(values, ", ")))
+	return nil
+}
+
+// emitterSignal prints the On... interface of a signal giving the signal path
+// (see emitterMethods), with its unexported payload. Its definition is declared
+// first in the table, to be the one used by Client.On.
+//
+func (g *generator) emitterSignal(iface introspect.Interface, signal introspect.Signal, table string) {
+	name := payloadName(iface, signal.Name)
+	payload := "emitter" + name
+
+	g.printf("\n// %s is the payload of the %s.%s signal for On%s.\n", payload, iface.Name, signal.Name, name)
+	g.printf("type %s struct {\n\tPath dbus.ObjectPath // Object emitting the signal.\n}\n", payload)
+	g.printf("\n// EventName returns the signal name of the event.\n")
+	g.printf("func (%s) EventName() string { return %q }\n", payload, eventName(iface.Name, signal.Name))
+
+	g.printf("\n// On%s is an interface to the %s method.\n", name, name)
+	g.printf("// The argument is the signal path, the core object path. See On%sPath.\n", name)
+	g.printf("type On%s interface {\n\t%s(dbus.ObjectPath)\n}\n", name, name)
+
+	g.printf("\nfunc decodeEmitter%s(m %sMsg) (ev %s, e error) {\n", name, g.qual, payload)
+	g.printf("\tev.Path = m.P\n\treturn ev, nil\n}\n")
+
+	g.events[table] = append(g.events[table], fmt.Sprintf("%sDefineEvent(decodeEmitter%s, func(o On%s, ev %s) {\no.%s(ev.Path)\n})",
+		g.qual, name, name, payload, name))
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"github.com/godbus/dbus/introspect"
+
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+const testXML = `<node>
+ <interface name="org.PulseAudio.Ext.Test1.Thing">
+  <method name="Resize">
+   <arg name="new_size" type="u" direction="in"/>
+   <arg name="type" type="s" direction="in"/>
+   <arg name="old_size" type="u" direction="out"/>
+  </method>
+  <property name="Size" type="u" access="read"/>
+  <property name="Volume" type="a(uu)" access="readwrite"/>
+  <property name="Secret" type="s" access="write"/>
+  <signal name="Resized">
+   <arg name="size" type="u"/>
+   <arg name="property_list" type="a{say}"/>
+  </signal>
+ </interface>
+ <interface name="org.freedesktop.DBus.Introspectable"/>
+</node>`
+
+func TestMergeNodes(t *testing.T) {
+	parse := func(data string) *introspect.Node {
+		node := &introspect.Node{}
+		if e := xml.Unmarshal([]byte(data), node); e != nil {
+			t.Fatal("parse:", e)
+		}
+		return node
+	}
+	device := parse(`<node><interface name="org.PulseAudio.Core1.Device"><property name="Name" type="s" access="read"/></interface></node>`)
+	sink := parse(`<node><interface name="org.PulseAudio.Core1.Device"/><interface name="org.PulseAudio.Core1.Sink"><property name="MonitorSource" type="o" access="read"/></interface></node>`)
+	other := parse(`<node><interface name="org.PulseAudio.Core1.Device"><property name="Index" type="u" access="read"/></interface></node>`)
+
+	ifaces, e := mergeNodes(sink, device, device)
+	if e != nil || len(ifaces) != 2 || ifaces[0].Name != "org.PulseAudio.Core1.Sink" || len(ifaces[1].Properties) != 1 {
+		t.Errorf("reference: got %+v (error %v), want the Sink and Device definitions", ifaces, e)
+	}
+	if _, e := mergeNodes(sink); e == nil {
+		t.Error("undefined reference: want an error")
+	}
+	if _, e := mergeNodes(device, other); e == nil {
+		t.Error("different definitions: want an error")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	node := &introspect.Node{}
+	e := xml.Unmarshal([]byte(testXML), node)
+	if e != nil {
+		t.Fatal("parse:", e)
+	}
+	ifaces, e := mergeNodes(node)
+	if e != nil || len(ifaces) != 1 {
+		t.Fatalf("interfaces: got %d (error %v), want 1", len(ifaces), e)
+	}
+	src, e := generate("test", ifaces)
+	if e != nil {
+		t.Fatal("generate:", e)
+	}
+	for _, want := range []string{
+		`ThingInterface = "org.PulseAudio.Ext.Test1.Thing"`,
+		"func (o Thing) Size() (val uint32, e error)",
+		"func (o Thing) Volume() (val []pulseaudio.ChannelVolume, e error)",
+		"func (o Thing) SetVolume(val []pulseaudio.ChannelVolume) error",
+		"func (o Thing) SetSecret(val string) error",
+		"func (o Thing) Resize(newSize uint32, arg1 string) (oldSize uint32, e error)",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if strings.Contains(string(src), "func (o Thing) SetSize") || strings.Contains(string(src), "func (o Thing) Secret") {
+		t.Error("access mode not respected")
+	}
+	if strings.Contains(string(src), "Resized") {
+		t.Error("signal generated with the objects")
+	}
+
+	src, e = generateEvents("pulseaudio", ifaces)
+	if e != nil {
+		t.Fatal("generate events:", e)
+	}
+	for _, want := range []string{
+		"PropertyList PropertyList",
+		`return "org.PulseAudio.Ext.Test1.Thing.Resized"`,
+		"ThingResized(dbus.ObjectPath, uint32, PropertyList)",
+		"e = StoreArgs(m, &ev.Size, &ev.PropertyList)",
+		"o.ThingResized(ev.Path, ev.Size, ev.PropertyList)",
+		"var ThingEvents = Events{",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("events: missing %q", want)
+		}
+	}
+	if strings.Contains(string(src), "pulseaudio.") {
+		t.Error("events: qualified types inside the pulseaudio package")
+	}
+}
+
+func TestEventNames(t *testing.T) {
+	for _, test := range []struct {
+		iface, signal  string
+		event, payload string
+		table          string
+	}{
+		{coreInterface, "NewSink", "NewSink", "NewSink", "PulseEvents"},
+		{coreInterface + ".Device", "VolumeUpdated", "Device.VolumeUpdated", "DeviceVolumeUpdated", "PulseEvents"},
+		{"org.PulseAudio.Ext.StreamRestore1.RestoreEntry", "MuteUpdated",
+			"org.PulseAudio.Ext.StreamRestore1.RestoreEntry.MuteUpdated", "RestoreEntryMuteUpdated", "StreamRestoreEvents"},
+		{"org.PulseAudio.Ext.Equalizing1.Manager", "SinkAdded",
+			"org.PulseAudio.Ext.Equalizing1.Manager.SinkAdded", "EqualizerSinkAdded", "EqualizerEvents"},
+	} {
+		iface := introspect.Interface{Name: test.iface}
+		event, payload, table := eventName(test.iface, test.signal), payloadName(iface, test.signal), tableName(iface)
+		if event != test.event || payload != test.payload || table != test.table {
+			t.Errorf("%s.%s: got %s %s %s, want %s %s %s", test.iface, test.signal,
+				event, payload, table, test.event, test.payload, test.table)
+		}
+	}
+}
+
+func TestGoType(t *testing.T) {
+	for sig, want := range map[string]string{
+		"u":      "uint32",
+		"ao":     "[]dbus.ObjectPath",
+		"a{ss}":  "map[string]string",
+		"a{say}": "pulseaudio.PropertyList",
+		"aau":    "[][]uint32",
+		"(ub)":   "[]interface{}",
+	} {
+		got, e := goType(sig)
+		if e != nil || got != want {
+			t.Errorf("type %s: got %s, %v, want %s", sig, got, e, want)
+		}
+	}
+	if _, e := goType("a{"); e == nil {
+		t.Error("type a{: expected an error")
+	}
+}
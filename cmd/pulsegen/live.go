@@ -0,0 +1,104 @@
+package main
+
+import (
+	"github.com/godbus/dbus"
+	"github.com/godbus/dbus/introspect"
+
+	"github.com/sqp/pulseaudio"
+
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Core properties listing the objects to introspect.
+var liveLists = []string{"Cards", "Sinks", "Sources", "PlaybackStreams", "RecordStreams", "Samples", "Modules", "Clients"}
+
+// Extensions objects to introspect, when loaded.
+var liveExtensions = map[string]dbus.ObjectPath{
+	pulseaudio.StreamRestoreInterface:    pulseaudio.StreamRestorePath,
+	pulseaudio.EqualizerManagerInterface: pulseaudio.EqualizerManagerPath,
+}
+
+// introspectLive returns the interfaces of the running server: the core, the
+// first object of every core list (and their children like ports and
+// profiles), and the loaded extensions.
+//
+// If dir isn't empty, every introspected object is saved as an XML file.
+//
+func introspectLive(dir string) ([]introspect.Interface, error) {
+	pulse, e := pulseaudio.New()
+	if e != nil {
+		return nil, e
+	}
+	defer pulse.Close()
+
+	core := pulse.Core()
+	paths := []dbus.ObjectPath{pulseaudio.DbusPath}
+	for _, name := range liveLists {
+		list, e := core.ListPath(name)
+		if e != nil {
+			return nil, e
+		}
+		if len(list) > 0 {
+			paths = append(paths, list[0])
+		}
+	}
+	exts, e := core.ListString("Extensions")
+	if e != nil {
+		return nil, e
+	}
+	for _, ext := range exts {
+		if path, ok := liveExtensions[ext]; ok {
+			paths = append(paths, path)
+		}
+	}
+
+	var nodes []*introspect.Node
+	dumped := make(map[string]bool)
+	for i := 0; i < len(paths); i++ {
+		node, e := introspect.Call(pulse.Object(pulseaudio.DbusInterface, paths[i]).BusObject)
+		if e != nil {
+			return nil, e
+		}
+		nodes = append(nodes, node)
+		if len(node.Children) > 0 { // Only the first child: all have the same interfaces.
+			paths = append(paths, paths[i]+"/"+dbus.ObjectPath(node.Children[0].Name))
+		}
+		if dir != "" {
+			e = dumpNode(dir, node, dumped)
+			if e != nil {
+				return nil, e
+			}
+		}
+	}
+	return mergeNodes(nodes...)
+}
+
+// dumpNode saves the introspection data of an object, in a file named from
+// its most specific interface (sink.xml for a sink). Interfaces already dumped
+// are saved as references, without their members.
+//
+func dumpNode(dir string, node *introspect.Node, dumped map[string]bool) error {
+	ifaces, e := mergeNodes(node)
+	if e != nil || len(ifaces) == 0 {
+		return e
+	}
+	saved := *node
+	saved.Interfaces = make([]introspect.Interface, len(node.Interfaces))
+	for i, iface := range node.Interfaces {
+		if dumped[iface.Name] {
+			iface = introspect.Interface{Name: iface.Name}
+		}
+		dumped[iface.Name] = true
+		saved.Interfaces[i] = iface
+	}
+	data, e := xml.MarshalIndent(saved, "", " ")
+	if e != nil {
+		return e
+	}
+	name := strings.ToLower(typeName(ifaces[len(ifaces)-1]))
+	data = append([]byte(strings.TrimSpace(introspect.IntrospectDeclarationString)+"\n"), data...)
+	return os.WriteFile(filepath.Join(dir, name+".xml"), append(data, '\n'), 0644)
+}
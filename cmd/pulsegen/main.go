@@ -0,0 +1,81 @@
+// Command pulsegen generates typed Go bindings for the pulseaudio Dbus
+// interfaces, from their introspection XML.
+//
+// Usage:
+//   pulsegen [-events] [-pkg name] [-out file] xml files or directories...
+//   pulsegen -live [-dump dir] [-events] [-pkg name] [-out file]
+//
+// By default, it writes for every interface an object type, with getters and
+// setters respecting the property access, and the method calls, with typed
+// arguments and results.
+//
+// With -events, it writes the signals: their payload types, On... interfaces,
+// and the Events tables to register with Client.AddEvents. The pulseaudio
+// package events are generated this way.
+//
+// The introspection data is read from files, or from a live server with -live
+// (the first object of every list is introspected). -dump saves the live data
+// as XML files, to check them in. See the pulsedbus package and the pulseaudio
+// events, generated from the files of the xml directory.
+//
+package main
+
+import (
+	"github.com/godbus/dbus/introspect"
+
+	"flag"
+	"fmt"
+	"os"
+)
+
+var (
+	out  = flag.String("out", "", "output file (default stdout)")
+	pkg  = flag.String("pkg", "pulsedbus", "package name of the generated file")
+	live = flag.Bool("live", false, "introspect the running pulseaudio server")
+	dump = flag.String("dump", "", "directory to save the live introspection XML")
+	evts = flag.Bool("events", false, "generate the signals instead of the objects")
+)
+
+func main() {
+	flag.Parse()
+
+	var ifaces []introspect.Interface
+	var e error
+	switch {
+	case *live:
+		ifaces, e = introspectLive(*dump)
+
+	case flag.NArg() == 0:
+		fmt.Fprintln(os.Stderr, "pulsegen: no input, give XML files or -live")
+		flag.Usage()
+		os.Exit(2)
+
+	default:
+		ifaces, e = readFiles(flag.Args())
+	}
+	if e != nil {
+		fatal("read:", e)
+	}
+
+	gen := generate
+	if *evts {
+		gen = generateEvents
+	}
+	src, e := gen(*pkg, ifaces)
+	if e != nil {
+		fatal("generate:", e)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	e = os.WriteFile(*out, src, 0644)
+	if e != nil {
+		fatal("write:", e)
+	}
+}
+
+func fatal(msg string, e error) {
+	fmt.Fprintln(os.Stderr, "pulsegen:", msg, e)
+	os.Exit(1)
+}
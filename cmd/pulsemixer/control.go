@@ -0,0 +1,196 @@
+package main
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+)
+
+// Volume settings, in percent.
+const (
+	volumeStep = 5
+	volumeMax  = 150
+)
+
+// handleKey applies the action of a key on the selected entry.
+//
+func (mix *mixer) handleKey(k key) {
+	mix.status = ""
+	switch k {
+	case keyUp:
+		if mix.selected > 0 {
+			mix.selected--
+		}
+		return
+
+	case keyDown:
+		if mix.selected < len(mix.entries)-1 {
+			mix.selected++
+		}
+		return
+
+	case keyReload:
+		mix.reload()
+		return
+	}
+
+	ent := mix.current()
+	if ent == nil {
+		return
+	}
+	switch k {
+	case keyLeft:
+		mix.setError(mix.changeVolume(ent, -volumeStep))
+
+	case keyRight:
+		mix.setError(mix.changeVolume(ent, volumeStep))
+
+	case keyMute:
+		if ent.hasMute {
+			mix.setError(mix.object(ent).Set("Mute", !ent.mute))
+		}
+
+	case keyMove:
+		mix.setError(mix.moveNext(ent))
+
+	case keyPort:
+		mix.setError(mix.nextPort(ent))
+
+	case keyProfile:
+		mix.setError(mix.nextProfile(ent))
+	}
+}
+
+// object returns the generic object of an entry.
+//
+func (mix *mixer) object(ent *entry) *pulseaudio.Object {
+	if ent.isDevice() {
+		return mix.pulse.Device(ent.path)
+	}
+	return mix.pulse.Stream(ent.path)
+}
+
+// changeVolume changes all channels of an entry by a percentage step,
+// keeping the balance between channels.
+//
+func (mix *mixer) changeVolume(ent *entry, step int) error {
+	if len(ent.volume) == 0 {
+		return nil
+	}
+	vols := make([]uint32, len(ent.volume))
+	for i, vol := range ent.volume {
+		vols[i] = stepVolume(vol, step)
+	}
+	return mix.object(ent).Set("Volume", vols)
+}
+
+// stepVolume changes a volume by a percentage step, within 0 and volumeMax.
+//
+func stepVolume(vol uint32, step int) uint32 {
+	value := int64(vol) + int64(step)*int64(pulseaudio.VolumeNorm)/100
+	switch max := int64(volumeMax) * int64(pulseaudio.VolumeNorm) / 100; {
+	case value < 0:
+		return 0
+	case value > max:
+		return uint32(max)
+	}
+	return uint32(value)
+}
+
+// moveNext moves a stream to the next device of its type.
+//
+func (mix *mixer) moveNext(ent *entry) error {
+	prop := "Sinks"
+	switch ent.kind {
+	case kindRecord:
+		prop = "Sources"
+	case kindSink, kindSource:
+		return nil
+	}
+
+	stream := mix.pulse.TypedStream(ent.path)
+	current, e := stream.Device()
+	if e != nil {
+		return e
+	}
+	devices, e := mix.pulse.Core().ListPath(prop)
+	if e != nil {
+		return e
+	}
+	next := nextPath(devices, current)
+	if next == current {
+		return nil
+	}
+	if e = stream.Move(next); e != nil {
+		return e
+	}
+	mix.load(ent)
+	return nil
+}
+
+// nextPort switches a device to its next port.
+//
+func (mix *mixer) nextPort(ent *entry) error {
+	if !ent.isDevice() {
+		return nil
+	}
+	dev := mix.pulse.TypedDevice(ent.path)
+	ports, e := dev.Ports()
+	if e != nil || len(ports) == 0 {
+		return e
+	}
+	active, e := dev.ActivePort()
+	if e != nil {
+		return e
+	}
+	paths := make([]dbus.ObjectPath, len(ports))
+	for i, port := range ports {
+		paths[i] = port.Path()
+	}
+	return dev.SetActivePort(nextPath(paths, active.Path()))
+}
+
+// nextProfile switches the card of a device to its next profile.
+// The device may be removed and replaced by the server.
+//
+func (mix *mixer) nextProfile(ent *entry) error {
+	if !ent.isDevice() {
+		return nil
+	}
+	card, e := mix.pulse.TypedDevice(ent.path).Card()
+	if e != nil {
+		return e
+	}
+	profiles, e := card.Profiles()
+	if e != nil || len(profiles) == 0 {
+		return e
+	}
+	active, e := card.ActiveProfile()
+	if e != nil {
+		return e
+	}
+	paths := make([]dbus.ObjectPath, len(profiles))
+	for i, profile := range profiles {
+		paths[i] = profile.Path()
+	}
+	if e = card.SetActiveProfile(nextPath(paths, active.Path())); e != nil {
+		return e
+	}
+	mix.reload()
+	return nil
+}
+
+// nextPath returns the path following current in the list, cycling to the
+// first. The first path is returned if current isn't found.
+//
+func nextPath(paths []dbus.ObjectPath, current dbus.ObjectPath) dbus.ObjectPath {
+	if len(paths) == 0 {
+		return current
+	}
+	for i, path := range paths {
+		if path == current {
+			return paths[(i+1)%len(paths)]
+		}
+	}
+	return paths[0]
+}
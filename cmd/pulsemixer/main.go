@@ -0,0 +1,85 @@
+// Command pulsemixer is a terminal mixer for a pulseaudio server.
+//
+// It shows sinks, sources, playback and record streams with live volume bars,
+// and controls them with the keyboard:
+//   up, down, j, k      Select an entry.
+//   left, right, h, l   Change the volume by 5%.
+//   m                   Toggle mute.
+//   d                   Move the selected stream to the next device.
+//   p                   Switch the selected device to its next port.
+//   c                   Switch the card of the selected device to its next profile.
+//   r                   Reload all entries.
+//   q, ctrl-c           Quit.
+//
+// The terminal is driven with ANSI sequences and the stty command, so no other
+// dependency is needed. The pulseaudio Dbus module must be loaded, see the
+// pulseaudio package doc.
+//
+package main
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"fmt"
+	"os"
+)
+
+func main() {
+	pulse, e := pulseaudio.New()
+	if e != nil {
+		fmt.Fprintln(os.Stderr, "pulsemixer: connect to the pulse service:", e)
+		os.Exit(1)
+	}
+	defer pulse.Close()
+
+	mix := newMixer(pulse)
+	mix.reload()
+
+	if errs := pulse.Register(mix); len(errs) > 0 {
+		mix.status = "listen events: " + errs[0].Error()
+	}
+	defer pulse.Unregister(mix)
+	go pulse.Listen()
+	defer pulse.StopListening()
+
+	term, e := openTerminal()
+	if e != nil {
+		fmt.Fprintln(os.Stderr, "pulsemixer: terminal:", e)
+		os.Exit(1)
+	}
+	defer term.close()
+
+	mix.run(term)
+}
+
+// run is the main loop, handling keys and pulseaudio events until quit.
+// All changes to the mixer are made in this loop.
+//
+func (mix *mixer) run(term *terminal) {
+	keys := term.keys()
+	for {
+		term.draw(mix)
+
+		select {
+		case k, ok := <-keys:
+			if !ok || k == keyQuit {
+				return
+			}
+			mix.handleKey(k)
+
+		case ev := <-mix.events:
+			ev()
+		}
+
+		// Apply all pending events before the next draw, to redraw once when
+		// many events are received (like a volume slider dragged).
+		for pending := true; pending; {
+			select {
+			case ev := <-mix.events:
+				ev()
+			default:
+				pending = false
+			}
+		}
+	}
+}
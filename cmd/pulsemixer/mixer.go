@@ -0,0 +1,213 @@
+package main
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+)
+
+// Entry kinds, in display order.
+const (
+	kindSink     = "Sinks"
+	kindSource   = "Sources"
+	kindPlayback = "PlaybackStreams"
+	kindRecord   = "RecordStreams"
+)
+
+var kinds = []string{kindSink, kindSource, kindPlayback, kindRecord}
+
+// entry is a displayed device or stream.
+//
+type entry struct {
+	kind    string
+	path    dbus.ObjectPath
+	name    string
+	volume  []uint32
+	mute    bool
+	hasMute bool   // Record streams can't be muted.
+	detail  string // Active port for devices, device name for streams.
+}
+
+func (e *entry) isDevice() bool { return e.kind == kindSink || e.kind == kindSource }
+
+// mixer holds the displayed entries and the pulseaudio events callbacks.
+//
+type mixer struct {
+	pulse    *pulseaudio.Client
+	entries  []*entry
+	selected int
+	status   string      // Last error.
+	events   chan func() // Pulseaudio events to apply in the main loop.
+}
+
+func newMixer(pulse *pulseaudio.Client) *mixer {
+	return &mixer{
+		pulse:  pulse,
+		events: make(chan func(), 100),
+	}
+}
+
+// reload queries all devices and streams.
+//
+func (mix *mixer) reload() {
+	var selected dbus.ObjectPath
+	if cur := mix.current(); cur != nil {
+		selected = cur.path
+	}
+
+	mix.entries = nil
+	for _, kind := range kinds {
+		paths, e := mix.pulse.Core().ListPath(kind)
+		if e != nil {
+			mix.setError(e)
+			continue
+		}
+		for _, path := range paths {
+			ent := &entry{kind: kind, path: path}
+			mix.load(ent)
+			mix.entries = append(mix.entries, ent)
+		}
+	}
+
+	mix.selected = 0
+	for i, ent := range mix.entries {
+		if ent.path == selected {
+			mix.selected = i
+		}
+	}
+}
+
+// load queries the properties of an entry.
+//
+func (mix *mixer) load(ent *entry) {
+	if ent.isDevice() {
+		dev := mix.pulse.TypedDevice(ent.path)
+		props, _ := dev.PropertyList()
+		ent.name = props.DeviceDescription()
+		if ent.name == "" {
+			ent.name, _ = dev.Name()
+		}
+		ent.volume, _ = dev.ListUint32("Volume")
+		ent.mute, _ = dev.Bool("Mute")
+		ent.hasMute = true
+		ent.detail = ""
+		if port, e := dev.ActivePort(); e == nil {
+			ent.detail, _ = port.Description()
+		}
+		return
+	}
+
+	stream := mix.pulse.TypedStream(ent.path)
+	props, _ := stream.PropertyList()
+	ent.name = props.ApplicationName()
+	if media := props.MediaName(); media != "" {
+		ent.name += ": " + media
+	}
+	ent.volume, _ = stream.ListUint32("Volume")
+	var e error
+	ent.mute, e = stream.Bool("Mute")
+	ent.hasMute = e == nil
+	ent.detail = ""
+	if devpath, e := stream.Device(); e == nil {
+		dev := mix.pulse.TypedDevice(devpath)
+		devprops, _ := dev.PropertyList()
+		ent.detail = devprops.DeviceDescription()
+	}
+}
+
+// current returns the selected entry.
+//
+func (mix *mixer) current() *entry {
+	if mix.selected < 0 || mix.selected >= len(mix.entries) {
+		return nil
+	}
+	return mix.entries[mix.selected]
+}
+
+// find returns the entry matching the path.
+//
+func (mix *mixer) find(path dbus.ObjectPath) *entry {
+	for _, ent := range mix.entries {
+		if ent.path == path {
+			return ent
+		}
+	}
+	return nil
+}
+
+func (mix *mixer) setError(e error) {
+	if e != nil {
+		mix.status = e.Error()
+	}
+}
+
+//
+//--------------------------------------------------------[ PULSE CALLBACKS ]--
+
+// The callbacks are called by the pulseaudio listen loop. Changes are sent to
+// the main loop to keep the mixer single threaded.
+
+// NewSink is called when a sink is added.
+func (mix *mixer) NewSink(path dbus.ObjectPath) { mix.events <- mix.reload }
+
+// SinkRemoved is called when a sink is removed.
+func (mix *mixer) SinkRemoved(path dbus.ObjectPath) { mix.events <- mix.reload }
+
+// NewSource is called when a source is added.
+func (mix *mixer) NewSource(path dbus.ObjectPath) { mix.events <- mix.reload }
+
+// SourceRemoved is called when a source is removed.
+func (mix *mixer) SourceRemoved(path dbus.ObjectPath) { mix.events <- mix.reload }
+
+// NewPlaybackStream is called when a playback stream is added.
+func (mix *mixer) NewPlaybackStream(path dbus.ObjectPath) { mix.events <- mix.reload }
+
+// PlaybackStreamRemoved is called when a playback stream is removed.
+func (mix *mixer) PlaybackStreamRemoved(path dbus.ObjectPath) { mix.events <- mix.reload }
+
+// NewRecordStream is called when a record stream is added.
+func (mix *mixer) NewRecordStream(path dbus.ObjectPath) { mix.events <- mix.reload }
+
+// RecordStreamRemoved is called when a record stream is removed.
+func (mix *mixer) RecordStreamRemoved(path dbus.ObjectPath) { mix.events <- mix.reload }
+
+// DeviceVolumeUpdated is called when the volume has changed on a device.
+func (mix *mixer) DeviceVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	mix.events <- func() { mix.setVolume(path, values) }
+}
+
+// StreamVolumeUpdated is called when the volume has changed on a stream.
+func (mix *mixer) StreamVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	mix.events <- func() { mix.setVolume(path, values) }
+}
+
+// DeviceMuteUpdated is called when a device has been (un)muted.
+func (mix *mixer) DeviceMuteUpdated(path dbus.ObjectPath, mute bool) {
+	mix.events <- func() { mix.setMute(path, mute) }
+}
+
+// StreamMuteUpdated is called when a stream has been (un)muted.
+func (mix *mixer) StreamMuteUpdated(path dbus.ObjectPath, mute bool) {
+	mix.events <- func() { mix.setMute(path, mute) }
+}
+
+// DeviceActivePortUpdated is called when the port has changed on a device.
+func (mix *mixer) DeviceActivePortUpdated(path, port dbus.ObjectPath) {
+	mix.events <- func() {
+		if ent := mix.find(path); ent != nil {
+			mix.load(ent)
+		}
+	}
+}
+
+func (mix *mixer) setVolume(path dbus.ObjectPath, values []uint32) {
+	if ent := mix.find(path); ent != nil {
+		ent.volume = values
+	}
+}
+
+func (mix *mixer) setMute(path dbus.ObjectPath, mute bool) {
+	if ent := mix.find(path); ent != nil {
+		ent.mute = mute
+	}
+}
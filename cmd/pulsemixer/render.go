@@ -0,0 +1,140 @@
+package main
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"fmt"
+	"strings"
+)
+
+const helpLine = "↑↓ select  ←→ volume  m mute  d move  p port  c profile  r reload  q quit"
+
+// sectionTitles defines the title displayed before entries of each kind.
+var sectionTitles = map[string]string{
+	kindSink:     "Output devices",
+	kindSource:   "Input devices",
+	kindPlayback: "Playback",
+	kindRecord:   "Recording",
+}
+
+// render returns the lines to display for the given terminal size.
+// Lines are scrolled to keep the selected entry visible.
+//
+func render(mix *mixer, rows, cols int) []string {
+	var lines []string
+	selectedLine := 0
+	kind := ""
+	for i, ent := range mix.entries {
+		if ent.kind != kind {
+			kind = ent.kind
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, truncate(sectionTitles[kind], cols))
+		}
+		line := renderEntry(ent, cols)
+		if i == mix.selected {
+			selectedLine = len(lines)
+			line = ansiReverse + line + ansiReset
+		}
+		lines = append(lines, line)
+	}
+	if len(mix.entries) == 0 {
+		lines = append(lines, "no devices")
+	}
+
+	// Keep room for the help and status lines.
+	height := rows - 2
+	if height < 1 {
+		height = 1
+	}
+	if len(lines) > height {
+		first := selectedLine - height/2
+		switch {
+		case first < 0:
+			first = 0
+		case first > len(lines)-height:
+			first = len(lines) - height
+		}
+		lines = lines[first : first+height]
+	}
+
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return append(lines, truncate(mix.status, cols), truncate(helpLine, cols))
+}
+
+// renderEntry formats an entry: name, volume bar, percent, mute and detail.
+//
+func renderEntry(ent *entry, cols int) string {
+	percent := volumePercent(ent.volume)
+	mute := "   "
+	if ent.mute {
+		mute = " M "
+	}
+	status := fmt.Sprintf(" %4d%%%s", percent, mute)
+
+	// Share the width between the name with detail and the bar.
+	nameWidth := (cols - len(status)) / 2
+	barWidth := cols - len(status) - nameWidth - 2
+	if barWidth < 0 {
+		barWidth = 0
+	}
+
+	name := ent.name
+	if ent.detail != "" {
+		name += " (" + ent.detail + ")"
+	}
+	return truncate(pad(" "+name, nameWidth)+volumeBar(percent, barWidth)+status, cols)
+}
+
+// volumeBar returns a bar of the given width filled to the volume percent.
+// Volumes over 100% fill the bar.
+//
+func volumeBar(percent, width int) string {
+	inner := width - 2
+	if inner <= 0 {
+		return ""
+	}
+	fill := percent * inner / 100
+	if fill > inner {
+		fill = inner
+	}
+	return "[" + strings.Repeat("#", fill) + strings.Repeat("-", inner-fill) + "]"
+}
+
+// volumePercent returns the highest channel volume in percent.
+//
+func volumePercent(vols []uint32) int {
+	var max uint32
+	for _, vol := range vols {
+		if vol > max {
+			max = vol
+		}
+	}
+	return int((uint64(max)*100 + uint64(pulseaudio.VolumeNorm)/2) / uint64(pulseaudio.VolumeNorm))
+}
+
+// truncate cuts a string to the given number of runes.
+//
+func truncate(str string, width int) string {
+	runes := []rune(str)
+	if width < 0 {
+		width = 0
+	}
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	return str
+}
+
+// pad truncates or pads a string with spaces to the given number of runes.
+//
+func pad(str string, width int) string {
+	str = truncate(str, width)
+	if n := width - len([]rune(str)); n > 0 {
+		str += strings.Repeat(" ", n)
+	}
+	return str
+}
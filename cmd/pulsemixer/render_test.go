@@ -0,0 +1,75 @@
+package main
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("jk\x1b[A\x1b[Dmxq\x03"))
+	want := []key{keyDown, keyUp, keyUp, keyLeft, keyMute, keyQuit, keyQuit}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parse keys: got %v, want %v", got, want)
+	}
+}
+
+func TestVolumeBar(t *testing.T) {
+	for _, test := range []struct {
+		percent, width int
+		want           string
+	}{
+		{50, 12, "[#####-----]"},
+		{0, 6, "[----]"},
+		{150, 6, "[####]"},
+		{50, 2, ""},
+	} {
+		if got := volumeBar(test.percent, test.width); got != test.want {
+			t.Errorf("volume bar %d%% width %d: got %q, want %q", test.percent, test.width, got, test.want)
+		}
+	}
+}
+
+func TestStepVolume(t *testing.T) {
+	for _, test := range []struct {
+		vol  uint32
+		step int
+		want uint32
+	}{
+		{65536, 5, 68812},
+		{65536, -5, 62260},
+		{1000, -5, 0},
+		{97000, 5, 98304},
+	} {
+		if got := stepVolume(test.vol, test.step); got != test.want {
+			t.Errorf("step volume %d by %d: got %d, want %d", test.vol, test.step, got, test.want)
+		}
+	}
+	if got := volumePercent([]uint32{32768, 65536}); got != 100 {
+		t.Errorf("volume percent: got %d, want 100", got)
+	}
+}
+
+func TestMixerHooks(t *testing.T) {
+	pulse := pulseaudio.NewReplayClient()
+	mix := newMixer(pulse)
+	if errs := pulse.Register(mix); len(errs) > 0 {
+		t.Fatal("register:", errs)
+	}
+
+	signals := []string{"NewSink", "SinkRemoved", "NewSource", "SourceRemoved",
+		"NewPlaybackStream", "PlaybackStreamRemoved", "NewRecordStream", "RecordStreamRemoved"}
+	for _, name := range signals {
+		pulse.DispatchSignal(&dbus.Signal{
+			Name: pulseaudio.DbusInterface + "." + name,
+			Path: pulseaudio.DbusPath,
+			Body: []interface{}{dbus.ObjectPath("/org/pulseaudio/core1/object0")},
+		})
+	}
+	if len(mix.events) != len(signals) {
+		t.Errorf("events: got %d, want %d", len(mix.events), len(signals))
+	}
+}
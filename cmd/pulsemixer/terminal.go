@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ANSI sequences.
+const (
+	ansiAltScreen  = "\x1b[?1049h"
+	ansiMainScreen = "\x1b[?1049l"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+	ansiHome       = "\x1b[H"
+	ansiClearEOL   = "\x1b[K"
+	ansiClearEOS   = "\x1b[J"
+	ansiReverse    = "\x1b[7m"
+	ansiReset      = "\x1b[0m"
+)
+
+// key is a parsed keyboard input.
+//
+type key int
+
+// Recognized keys.
+const (
+	keyNone key = iota
+	keyQuit
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyMute
+	keyMove
+	keyPort
+	keyProfile
+	keyReload
+)
+
+// terminal is the raw mode terminal the mixer is drawn on.
+//
+type terminal struct {
+	saved string // stty state to restore.
+}
+
+// openTerminal switches the terminal to raw mode on the alternate screen.
+//
+func openTerminal() (*terminal, error) {
+	saved, e := stty("-g")
+	if e != nil {
+		return nil, e
+	}
+	if _, e = stty("raw", "-echo"); e != nil {
+		return nil, e
+	}
+	os.Stdout.WriteString(ansiAltScreen + ansiHideCursor)
+	return &terminal{saved: strings.TrimSpace(saved)}, nil
+}
+
+// close restores the terminal.
+//
+func (term *terminal) close() {
+	os.Stdout.WriteString(ansiShowCursor + ansiMainScreen)
+	stty(term.saved)
+}
+
+// size returns the terminal size, with a fallback to 24x80.
+//
+func (term *terminal) size() (rows, cols int) {
+	out, e := stty("size")
+	if e != nil {
+		return 24, 80
+	}
+	if n, _ := fmt.Sscan(out, &rows, &cols); n != 2 || rows <= 0 || cols <= 0 {
+		return 24, 80
+	}
+	return rows, cols
+}
+
+// keys reads the keyboard input. The channel is closed when stdin is closed.
+//
+func (term *terminal) keys() <-chan key {
+	c := make(chan key)
+	go func() {
+		defer close(c)
+		buf := make([]byte, 16)
+		for {
+			n, e := os.Stdin.Read(buf)
+			if e != nil {
+				return
+			}
+			for _, k := range parseKeys(buf[:n]) {
+				c <- k
+			}
+		}
+	}()
+	return c
+}
+
+// draw renders the mixer on the terminal.
+//
+func (term *terminal) draw(mix *mixer) {
+	rows, cols := term.size()
+	var buf bytes.Buffer
+	buf.WriteString(ansiHome)
+	for _, line := range render(mix, rows, cols) {
+		buf.WriteString(line)
+		buf.WriteString(ansiClearEOL + "\r\n")
+	}
+	buf.WriteString(ansiClearEOS)
+	os.Stdout.Write(buf.Bytes())
+}
+
+// parseKeys parses the keys of a raw input.
+//
+func parseKeys(input []byte) (keys []key) {
+	for i := 0; i < len(input); i++ {
+		k := keyNone
+		switch input[i] {
+		case 'q', 3: // ctrl-c
+			k = keyQuit
+		case 'k':
+			k = keyUp
+		case 'j':
+			k = keyDown
+		case 'h':
+			k = keyLeft
+		case 'l':
+			k = keyRight
+		case 'm':
+			k = keyMute
+		case 'd':
+			k = keyMove
+		case 'p':
+			k = keyPort
+		case 'c':
+			k = keyProfile
+		case 'r':
+			k = keyReload
+		case 0x1b: // Arrow keys: ESC [ A-D.
+			if i+2 < len(input) && input[i+1] == '[' {
+				switch input[i+2] {
+				case 'A':
+					k = keyUp
+				case 'B':
+					k = keyDown
+				case 'C':
+					k = keyRight
+				case 'D':
+					k = keyLeft
+				}
+				i += 2
+			}
+		}
+		if k != keyNone {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// stty runs the stty command on the terminal.
+//
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, e := cmd.Output()
+	return string(out), e
+}
@@ -0,0 +1,150 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCoalesced lists the high frequency events coalesced by
+// RegisterCoalesced when no event name is given.
+//
+var DefaultCoalesced = []string{"Device.VolumeUpdated", "Stream.VolumeUpdated"}
+
+// RegisterCoalesced connects an object to the pulseaudio events hooks like
+// Register, with the given events coalesced over window: for each event name
+// and object path, only the latest event received during the window is
+// delivered, at the end of the window.
+//
+// Events not listed are delivered immediately, so add-remove events like
+// SinkRemoved are never merged unless asked. Without names, DefaultCoalesced
+// is used.
+//
+// Coalesced events are delivered from timer goroutines, not from the Listen
+// loop: the object callbacks must be safe for concurrent use. Pending events
+// are dropped when the object is unregistered, and when the object emitting
+// them is removed (SinkRemoved, PlaybackStreamRemoved...).
+//
+// The object must be comparable (a pointer in most cases) to be coalesced.
+// Other objects are registered with their events delivered immediately.
+//
+//   pulse.RegisterCoalesced(osd, 100*time.Millisecond)
+//
+func (pulse *Client) RegisterCoalesced(obj interface{}, window time.Duration, names ...string) (errs []error) {
+	if len(names) == 0 {
+		names = DefaultCoalesced
+	}
+	pulse.hooker.Coalesce(obj, window, names...)
+	return pulse.Register(obj)
+}
+
+// Coalesce sets events of a client object to be coalesced over window.
+// See Client.RegisterCoalesced.
+//
+func (hook Hooker) Coalesce(obj interface{}, window time.Duration, names ...string) {
+	if !reflect.TypeOf(obj).Comparable() {
+		return
+	}
+	if old, ok := hook.coalesced[obj]; ok {
+		old.stop()
+	}
+	c := &coalescer{
+		window:  window,
+		names:   make(map[string]bool),
+		pending: make(map[coalesceKey]*Msg),
+	}
+	for _, name := range names {
+		c.names[name] = true
+	}
+	hook.coalesced[obj] = c
+}
+
+// coalescerOf returns the coalescer of a client object. Objects that can't be
+// map keys are never coalesced.
+//
+func (hook Hooker) coalescerOf(obj interface{}) (*coalescer, bool) {
+	if len(hook.coalesced) == 0 || !reflect.TypeOf(obj).Comparable() {
+		return nil, false
+	}
+	c, ok := hook.coalesced[obj]
+	return c, ok
+}
+
+// coalescer delays and merges the events of a client object.
+//
+type coalescer struct {
+	window  time.Duration
+	names   map[string]bool
+	pending map[coalesceKey]*Msg // Latest event waiting for the end of its window.
+	stopped bool
+	mu      sync.Mutex
+}
+
+// coalesceKey identifies the merged events.
+//
+type coalesceKey struct {
+	name string
+	path dbus.ObjectPath
+}
+
+// push queues an event. The first event of a key starts its window, the next
+// ones replace the queued event.
+//
+func (c *coalescer) push(name string, call func(Msg), msg Msg) {
+	key := coalesceKey{name, msg.P}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return
+	}
+	if queued, ok := c.pending[key]; ok {
+		*queued = msg
+		return
+	}
+	c.pending[key] = &msg
+
+	time.AfterFunc(c.window, func() {
+		c.mu.Lock()
+		latest, ok := c.pending[key]
+		delete(c.pending, key)
+		c.mu.Unlock()
+		if ok {
+			call(*latest)
+		}
+	})
+}
+
+// drop removes the pending events emitted by an object.
+//
+func (c *coalescer) drop(path dbus.ObjectPath) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.pending {
+		if key.path == path {
+			delete(c.pending, key)
+		}
+	}
+}
+
+// stop drops the pending events.
+//
+func (c *coalescer) stop() {
+	c.mu.Lock()
+	c.stopped = true
+	c.pending = make(map[coalesceKey]*Msg)
+	c.mu.Unlock()
+}
+
+// removedPath returns the object removed by a signal, for the ...Removed
+// signals with the object path as data.
+//
+func removedPath(name string, s *dbus.Signal) (dbus.ObjectPath, bool) {
+	if !strings.HasSuffix(name, "Removed") || len(s.Body) == 0 {
+		return "", false
+	}
+	path, ok := s.Body[0].(dbus.ObjectPath)
+	return path, ok
+}
@@ -0,0 +1,110 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type volumeRecorder struct {
+	mu      sync.Mutex
+	volumes map[dbus.ObjectPath][][]uint32
+	removed []dbus.ObjectPath
+}
+
+func (rec *volumeRecorder) DeviceVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	rec.mu.Lock()
+	rec.volumes[path] = append(rec.volumes[path], values)
+	rec.mu.Unlock()
+}
+
+func (rec *volumeRecorder) SinkRemovedPath(path dbus.ObjectPath) {
+	rec.mu.Lock()
+	rec.removed = append(rec.removed, path)
+	rec.mu.Unlock()
+}
+
+func TestHookerCoalesce(t *testing.T) {
+	hook := pulseaudio.NewHooker()
+	hook.AddEvents(pulseaudio.PulseEvents)
+
+	rec := &volumeRecorder{volumes: make(map[dbus.ObjectPath][][]uint32)}
+	hook.Coalesce(rec, 50*time.Millisecond, pulseaudio.DefaultCoalesced...)
+	hook.Register(rec)
+
+	for i := uint32(1); i <= 10; i++ {
+		hook.Call("Device.VolumeUpdated", &dbus.Signal{Path: "/sink0", Body: []interface{}{[]uint32{i}}})
+	}
+	hook.Call("Device.VolumeUpdated", &dbus.Signal{Path: "/sink1", Body: []interface{}{[]uint32{42}}})
+	hook.Call("SinkRemoved", &dbus.Signal{Body: []interface{}{dbus.ObjectPath("/sink2")}})
+
+	rec.mu.Lock()
+	if len(rec.volumes) != 0 || len(rec.removed) != 1 {
+		t.Errorf("before window: got volumes %v, removed %v, want only the removed event", rec.volumes, rec.removed)
+	}
+	rec.mu.Unlock()
+
+	time.Sleep(150 * time.Millisecond)
+	want := map[dbus.ObjectPath][][]uint32{"/sink0": {{10}}, "/sink1": {{42}}}
+	rec.mu.Lock()
+	if !reflect.DeepEqual(rec.volumes, want) {
+		t.Errorf("after window: got %v, want %v", rec.volumes, want)
+	}
+	rec.mu.Unlock()
+
+	// Pending events are dropped when the object emitting them is removed.
+	hook.Call("Device.VolumeUpdated", &dbus.Signal{Path: "/sink1", Body: []interface{}{[]uint32{43}}})
+	hook.Call("SinkRemoved", &dbus.Signal{Body: []interface{}{dbus.ObjectPath("/sink1")}})
+	time.Sleep(100 * time.Millisecond)
+	rec.mu.Lock()
+	if len(rec.volumes["/sink1"]) != 1 {
+		t.Errorf("after removal: got %v, want no new event", rec.volumes["/sink1"])
+	}
+	rec.mu.Unlock()
+
+	// Pending events are dropped on unregister.
+	hook.Call("Device.VolumeUpdated", &dbus.Signal{Path: "/sink0", Body: []interface{}{[]uint32{11}}})
+	hook.Unregister(rec)
+	time.Sleep(100 * time.Millisecond)
+	rec.mu.Lock()
+	if len(rec.volumes["/sink0"]) != 1 {
+		t.Errorf("after unregister: got %v, want no new event", rec.volumes["/sink0"])
+	}
+	rec.mu.Unlock()
+}
+
+// volumeList is a value type that can't be used as a map key.
+//
+type volumeList struct {
+	volumes *[][]uint32
+	paths   []dbus.ObjectPath
+}
+
+func (list volumeList) DeviceVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	*list.volumes = append(*list.volumes, values)
+}
+
+func TestHookerCoalesceNotComparable(t *testing.T) {
+	hook := pulseaudio.NewHooker()
+	hook.AddEvents(pulseaudio.PulseEvents)
+
+	rec := &volumeRecorder{volumes: make(map[dbus.ObjectPath][][]uint32)}
+	hook.Coalesce(rec, time.Hour, pulseaudio.DefaultCoalesced...)
+	hook.Register(rec)
+
+	var volumes [][]uint32
+	list := volumeList{volumes: &volumes}
+	hook.Coalesce(list, time.Hour, pulseaudio.DefaultCoalesced...)
+	hook.Register(list)
+
+	hook.Call("Device.VolumeUpdated", &dbus.Signal{Path: "/sink0", Body: []interface{}{[]uint32{42}}})
+	if want := [][]uint32{{42}}; !reflect.DeepEqual(volumes, want) {
+		t.Errorf("got %v, want %v delivered immediately", volumes, want)
+	}
+	hook.Unregister(rec)
+}
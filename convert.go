@@ -0,0 +1,287 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"fmt"
+	"reflect"
+)
+
+// StoreValue assigns a value received from Dbus to dest, which must be a
+// non-nil pointer.
+//
+// The conversion is more permissive than a direct assignment, following the
+// dbus.Store semantics:
+//   numeric   Any integer or floating point value can be stored in any numeric
+//             type able to hold it without loss. Overflows are errors, as are
+//             integers too large to be exact in a float (above 2^53 for
+//             float64, 2^24 for float32).
+//   string    Strings, ObjectPath and Signature are interchangeable.
+//   slices    Converted element by element (also to arrays of matching size).
+//   maps      Converted key by key and value by value.
+//   structs   Dbus structs (received as []interface{}) are stored in the
+//             exported fields of a Go struct, in declaration order.
+//   variants  Are unwrapped before conversion, or kept if dest is a Variant.
+//   interface Any value can be stored in an interface it implements.
+//
+// The PropertyList special case (map[string][]byte to map[string]string) is
+// handled by removing the trailing \0 of every value. Use the PropertyList type
+// to get the raw values.
+//
+func StoreValue(src, dest interface{}) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("store: dest must be a non-nil pointer, got %T", dest)
+	}
+	if src == nil {
+		return fmt.Errorf("store: nil value to %T", dest)
+	}
+	return storeValue(rv.Elem(), reflect.ValueOf(src))
+}
+
+var (
+	variantType    = reflect.TypeOf(dbus.Variant{})
+	propertiesType = reflect.TypeOf(map[string][]byte{})
+	mapStringType  = reflect.TypeOf(map[string]string{})
+)
+
+// storeValue converts src into dest, dest being a settable value.
+//
+func storeValue(dest, src reflect.Value) error {
+	// Unwrap interfaces and variants, unless the dest asks for a variant.
+	for src.Kind() == reflect.Interface && !src.IsNil() {
+		src = src.Elem()
+	}
+	if src.Type() == variantType && dest.Type() != variantType {
+		val := src.Interface().(dbus.Variant).Value()
+		if val == nil {
+			return fmt.Errorf("store: empty variant to %s", dest.Type())
+		}
+		return storeValue(dest, reflect.ValueOf(val))
+	}
+
+	switch {
+	case src.Type() == propertiesType && dest.Type() == mapStringType:
+		dest.Set(reflect.ValueOf(propertiesToStrings(src.Interface().(map[string][]byte))))
+		return nil
+
+	case src.Type().AssignableTo(dest.Type()):
+		dest.Set(src)
+		return nil
+
+	case dest.Type() == variantType:
+		dest.Set(reflect.ValueOf(dbus.MakeVariant(src.Interface())))
+		return nil
+
+	case dest.Kind() == reflect.Ptr:
+		if dest.IsNil() {
+			dest.Set(reflect.New(dest.Type().Elem()))
+		}
+		return storeValue(dest.Elem(), src)
+	}
+
+	switch dest.Kind() {
+	case reflect.Bool:
+		if src.Kind() == reflect.Bool {
+			dest.SetBool(src.Bool())
+			return nil
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64:
+
+		return storeNumeric(dest, src)
+
+	case reflect.String:
+		if src.Kind() == reflect.String {
+			dest.SetString(src.String())
+			return nil
+		}
+
+	case reflect.Slice:
+		if src.Kind() == reflect.Slice || src.Kind() == reflect.Array {
+			list := reflect.MakeSlice(dest.Type(), src.Len(), src.Len())
+			if e := storeList(list, src); e != nil {
+				return e
+			}
+			dest.Set(list)
+			return nil
+		}
+
+	case reflect.Array:
+		if (src.Kind() == reflect.Slice || src.Kind() == reflect.Array) && src.Len() == dest.Len() {
+			return storeList(dest, src)
+		}
+
+	case reflect.Map:
+		if src.Kind() == reflect.Map {
+			return storeMap(dest, src)
+		}
+
+	case reflect.Struct:
+		if src.Kind() == reflect.Slice && src.Type().Elem().Kind() == reflect.Interface {
+			return storeStruct(dest, src)
+		}
+		if src.Kind() == reflect.Struct {
+			return storeStruct(dest, structFields(src))
+		}
+	}
+	return fmt.Errorf("store: cannot convert %s to %s", src.Type(), dest.Type())
+}
+
+// storeNumeric converts any numeric value into a numeric dest, refusing to
+// lose data.
+//
+func storeNumeric(dest, src reflect.Value) error {
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := src.Int()
+		switch dest.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if max := floatExactMax(dest); v <= int64(max) && v >= -int64(max) {
+				dest.SetFloat(float64(v))
+				return nil
+			}
+			return fmt.Errorf("store: %d loses precision in %s", v, dest.Type())
+
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !dest.OverflowInt(v) {
+				dest.SetInt(v)
+				return nil
+			}
+
+		default:
+			if v >= 0 && !dest.OverflowUint(uint64(v)) {
+				dest.SetUint(uint64(v))
+				return nil
+			}
+		}
+		return fmt.Errorf("store: %d overflows %s", v, dest.Type())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v := src.Uint()
+		switch dest.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if v <= floatExactMax(dest) {
+				dest.SetFloat(float64(v))
+				return nil
+			}
+			return fmt.Errorf("store: %d loses precision in %s", v, dest.Type())
+
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if int64(v) >= 0 && !dest.OverflowInt(int64(v)) {
+				dest.SetInt(int64(v))
+				return nil
+			}
+
+		default:
+			if !dest.OverflowUint(v) {
+				dest.SetUint(v)
+				return nil
+			}
+		}
+		return fmt.Errorf("store: %d overflows %s", v, dest.Type())
+
+	case reflect.Float32, reflect.Float64:
+		v := src.Float()
+		switch dest.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if !dest.OverflowFloat(v) {
+				dest.SetFloat(v)
+				return nil
+			}
+			return fmt.Errorf("store: %g overflows %s", v, dest.Type())
+		}
+	}
+	return fmt.Errorf("store: cannot convert %s to %s", src.Type(), dest.Type())
+}
+
+// floatExactMax returns the largest integer a float dest can hold exactly.
+//
+func floatExactMax(dest reflect.Value) uint64 {
+	if dest.Kind() == reflect.Float32 {
+		return 1 << 24
+	}
+	return 1 << 53
+}
+
+// storeList converts every element of src into the matching element of dest.
+//
+func storeList(dest, src reflect.Value) error {
+	for i := 0; i < src.Len(); i++ {
+		if e := storeValue(dest.Index(i), src.Index(i)); e != nil {
+			return fmt.Errorf("index %d: %v", i, e)
+		}
+	}
+	return nil
+}
+
+// storeMap converts every key and value of src into a new map set to dest.
+//
+func storeMap(dest, src reflect.Value) error {
+	m := reflect.MakeMapWithSize(dest.Type(), src.Len())
+	for _, key := range src.MapKeys() {
+		k := reflect.New(dest.Type().Key()).Elem()
+		if e := storeValue(k, key); e != nil {
+			return fmt.Errorf("key %v: %v", key, e)
+		}
+		v := reflect.New(dest.Type().Elem()).Elem()
+		if e := storeValue(v, src.MapIndex(key)); e != nil {
+			return fmt.Errorf("key %v: %v", key, e)
+		}
+		m.SetMapIndex(k, v)
+	}
+	dest.Set(m)
+	return nil
+}
+
+// storeStruct converts a list of fields values into the exported fields of
+// the dest struct.
+//
+func storeStruct(dest, src reflect.Value) error {
+	fields := exportedFields(dest.Type())
+	if len(fields) != src.Len() {
+		return fmt.Errorf("store: cannot convert %d fields to %s (%d fields)",
+			src.Len(), dest.Type(), len(fields))
+	}
+	for i, field := range fields {
+		if e := storeValue(dest.Field(field), src.Index(i)); e != nil {
+			return fmt.Errorf("field %s: %v", dest.Type().Field(field).Name, e)
+		}
+	}
+	return nil
+}
+
+// structFields returns the exported fields values of a struct as a list.
+//
+func structFields(src reflect.Value) reflect.Value {
+	fields := exportedFields(src.Type())
+	list := make([]interface{}, len(fields))
+	for i, field := range fields {
+		list[i] = src.Field(field).Interface()
+	}
+	return reflect.ValueOf(list)
+}
+
+// exportedFields returns the index of exported fields of a struct type.
+//
+func exportedFields(t reflect.Type) (fields []int) {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			fields = append(fields, i)
+		}
+	}
+	return fields
+}
+
+// propertiesToStrings converts a Dbus property list to a strings map.
+// Dbus text values are \0 terminated, the terminator is removed if found.
+//
+func propertiesToStrings(val map[string][]byte) map[string]string {
+	tmp := make(map[string]string, len(val))
+	for k, v := range val {
+		tmp[k] = trimNul(v)
+	}
+	return tmp
+}
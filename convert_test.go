@@ -0,0 +1,170 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStoreValue(t *testing.T) {
+	type memstats struct {
+		Count uint32
+		Size  uint32
+	}
+
+	for _, test := range []struct {
+		src  interface{}
+		dest interface{} // pointer to the type to convert to.
+		want interface{}
+	}{
+		{true, new(bool), true},
+		{uint32(42), new(uint32), uint32(42)},
+		{uint32(42), new(int), 42},
+		{int32(-3), new(int64), int64(-3)},
+		{byte(7), new(uint32), uint32(7)},
+		{uint32(1), new(float64), float64(1)},
+		{float64(0.5), new(float32), float32(0.5)},
+		{"name", new(string), "name"},
+		{dbus.ObjectPath("/a"), new(string), "/a"},
+		{"/a", new(dbus.ObjectPath), dbus.ObjectPath("/a")},
+		{[]byte{1, 2}, new([]byte), []byte{1, 2}},
+		{[]uint32{1, 2}, new([]int), []int{1, 2}},
+		{[]float64{1, 2}, new([2]float64), [2]float64{1, 2}},
+		{[]dbus.ObjectPath{"/a"}, new([]string), []string{"/a"}},
+		{[][]uint32{{1}, {2, 3}}, new([][]uint64), [][]uint64{{1}, {2, 3}}},
+		{map[uint32]float64{1: 2}, new(map[int]float32), map[int]float32{1: 2}},
+		{[]interface{}{uint32(1), uint32(2)}, new(memstats), memstats{1, 2}},
+		{[][]interface{}{{uint32(1), uint32(2)}}, new([]memstats), []memstats{{1, 2}}},
+		{dbus.MakeVariant(uint32(3)), new(uint64), uint64(3)},
+		{uint32(3), new(dbus.Variant), dbus.MakeVariant(uint32(3))},
+		{uint32(3), new(interface{}), uint32(3)},
+		{uint32(3), new(*uint32), func() *uint32 { v := uint32(3); return &v }()},
+		{map[string][]byte{"a": []byte("b\x00")}, new(map[string]string), map[string]string{"a": "b"}},
+		{map[string][]byte{"a": {1, 2}, "b": {}}, new(map[string]string), map[string]string{"a": "\x01\x02", "b": ""}},
+		{map[string][]byte{"a": {1, 0}}, new(pulseaudio.PropertyList), pulseaudio.PropertyList{"a": {1, 0}}},
+	} {
+		e := pulseaudio.StoreValue(test.src, test.dest)
+		if e != nil {
+			t.Errorf("store %#v to %T: %v", test.src, test.dest, e)
+			continue
+		}
+		got := reflect.ValueOf(test.dest).Elem().Interface()
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("store %#v to %T: got %#v, want %#v", test.src, test.dest, got, test.want)
+		}
+	}
+}
+
+func TestStoreValueErrors(t *testing.T) {
+	for _, test := range []struct {
+		src  interface{}
+		dest interface{}
+	}{
+		{uint32(1), nil},
+		{uint32(1), uint32(0)},
+		{nil, new(uint32)},
+		{uint32(300), new(byte)},
+		{int32(-1), new(uint32)},
+		{uint64(math.MaxUint64), new(int64)},
+		{float64(0.5), new(int)},
+		{math.MaxFloat64, new(float32)},
+		{uint64(1<<53 + 1), new(float64)},
+		{int64(-1<<53 - 1), new(float64)},
+		{uint32(1<<24 + 1), new(float32)},
+		{"1", new(int)},
+		{true, new(string)},
+		{[]uint32{1, 2}, new([3]uint32)},
+		{[]interface{}{uint32(1)}, new(struct{ A, B uint32 })},
+		{map[string]uint32{"a": 1}, new(map[int]uint32)},
+	} {
+		if e := pulseaudio.StoreValue(test.src, test.dest); e == nil {
+			t.Errorf("store %#v to %T: expected an error", test.src, test.dest)
+		}
+	}
+}
+
+func FuzzStoreValueNumeric(f *testing.F) {
+	f.Add(int64(0), uint64(0), float64(0))
+	f.Add(int64(-1), uint64(math.MaxUint32), float64(0.5))
+	f.Add(int64(math.MinInt64), uint64(math.MaxUint64), math.MaxFloat64)
+
+	dests := []interface{}{
+		new(byte), new(int16), new(uint16), new(int32), new(uint32),
+		new(int64), new(uint64), new(int), new(float32), new(float64),
+	}
+
+	f.Fuzz(func(t *testing.T, i int64, u uint64, fl float64) {
+		for _, src := range []interface{}{i, u, fl} {
+			for _, dest := range dests {
+				if e := pulseaudio.StoreValue(src, dest); e != nil {
+					continue
+				}
+				// A successful integer conversion must be lossless.
+				got := reflect.ValueOf(dest).Elem()
+				isFloat := got.Kind() == reflect.Float32 || got.Kind() == reflect.Float64
+				switch v := src.(type) {
+				case int64:
+					if (isFloat && got.Float() != float64(v)) || (!isFloat && asInt(got) != v) {
+						t.Fatalf("store %d to %s: got %v", v, got.Type(), got)
+					}
+				case uint64:
+					if (isFloat && got.Float() != float64(v)) || (!isFloat && asUint(got) != v) {
+						t.Fatalf("store %d to %s: got %v", v, got.Type(), got)
+					}
+				}
+			}
+		}
+	})
+}
+
+func FuzzStoreValueList(f *testing.F) {
+	f.Add([]byte{}, "")
+	f.Add([]byte{0, 1, 255}, "/org/pulseaudio/core1")
+
+	f.Fuzz(func(t *testing.T, data []byte, str string) {
+		var list []uint32
+		if e := pulseaudio.StoreValue(data, &list); e != nil {
+			t.Fatalf("store %v to []uint32: %v", data, e)
+		}
+		for i, v := range list {
+			if uint32(data[i]) != v {
+				t.Fatalf("store %v to []uint32: got %v", data, list)
+			}
+		}
+
+		var path dbus.ObjectPath
+		if e := pulseaudio.StoreValue(str, &path); e != nil || string(path) != str {
+			t.Fatalf("store %q to ObjectPath: got %q, %v", str, path, e)
+		}
+
+		props := map[string][]byte{str: data}
+		var strs map[string]string
+		if e := pulseaudio.StoreValue(props, &strs); e != nil {
+			t.Fatalf("store %v to map[string]string: %v", props, e)
+		}
+		if got, ok := strs[str]; !ok || got != strings.TrimSuffix(string(data), "\x00") {
+			t.Fatalf("store %v to map[string]string: got %q", props, strs[str])
+		}
+	})
+}
+
+func asInt(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	}
+	return int64(v.Uint())
+}
+
+func asUint(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(v.Int())
+	}
+	return v.Uint()
+}
@@ -0,0 +1,283 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"strconv"
+	"sync"
+)
+
+// VolumeNorm is the normal volume (100%, no amplification or attenuation).
+//
+const VolumeNorm uint32 = 0x10000
+
+// SinkByName finds a sink by its name.
+//
+func (pulse *Client) SinkByName(name string) (*Device, error) {
+	var path dbus.ObjectPath
+	e := pulse.Core().Call(DbusInterface+".GetSinkByName", 0, name).Store(&path)
+	if e != nil {
+		return nil, e
+	}
+	return pulse.TypedDevice(path), nil
+}
+
+// SourceByName finds a source by its name.
+//
+func (pulse *Client) SourceByName(name string) (*Device, error) {
+	var path dbus.ObjectPath
+	e := pulse.Core().Call(DbusInterface+".GetSourceByName", 0, name).Store(&path)
+	if e != nil {
+		return nil, e
+	}
+	return pulse.TypedDevice(path), nil
+}
+
+// Device is a typed access to a pulseaudio device (sink or source).
+// See Client.Device for the properties list.
+//
+// The generic Object methods are still available for other properties.
+//
+type Device struct {
+	*Object
+	pulse *Client
+}
+
+// TypedDevice returns a typed access to a pulseaudio device (sink or source).
+//
+func (pulse *Client) TypedDevice(path dbus.ObjectPath) *Device {
+	return &Device{pulse.Device(path), pulse}
+}
+
+// Name returns the device name.
+//
+func (dev *Device) Name() (string, error) {
+	return dev.String("Name")
+}
+
+// PropertyList returns the device property list.
+//
+func (dev *Device) PropertyList() (PropertyList, error) {
+	return dev.Object.PropertyList("PropertyList")
+}
+
+// Ports returns all available device ports. May be empty.
+//
+func (dev *Device) Ports() ([]*DevicePort, error) {
+	paths, e := dev.ListPath("Ports")
+	if e != nil {
+		return nil, e
+	}
+	ports := make([]*DevicePort, len(paths))
+	for i, path := range paths {
+		ports[i] = dev.pulse.DevicePort(path)
+	}
+	return ports, nil
+}
+
+// ActivePort returns the currently active device port.
+// The property doesn't exist if the device does not have any ports.
+//
+func (dev *Device) ActivePort() (*DevicePort, error) {
+	path, e := dev.ObjectPath("ActivePort")
+	if e != nil {
+		return nil, e
+	}
+	return dev.pulse.DevicePort(path), nil
+}
+
+// SetActivePort sets the active device port.
+//
+func (dev *Device) SetActivePort(port dbus.ObjectPath) error {
+	return dev.Set("ActivePort", port)
+}
+
+// PortByName finds a port of the device by its name.
+//
+func (dev *Device) PortByName(name string) (*DevicePort, error) {
+	var path dbus.ObjectPath
+	e := dev.Call(dev.prefix+".GetPortByName", 0, name).Store(&path)
+	if e != nil {
+		return nil, e
+	}
+	return dev.pulse.DevicePort(path), nil
+}
+
+// Card returns the card this device belongs to.
+// The property doesn't exist if the device isn't part of a card.
+//
+func (dev *Device) Card() (*Card, error) {
+	path, e := dev.ObjectPath("Card")
+	if e != nil {
+		return nil, e
+	}
+	return dev.pulse.Card(path), nil
+}
+
+// State returns the current state of the device.
+//
+func (dev *Device) State() (DeviceState, error) {
+	state, e := dev.Uint32("State")
+	return DeviceState(state), e
+}
+
+// Suspend suspends the device.
+// A device suspended this way stays suspended until resumed, even if new
+// streams are connected.
+//
+func (dev *Device) Suspend() error {
+	return dev.Call(dev.prefix+".Suspend", 0, true).Err
+}
+
+// Resume unsuspends the device.
+//
+func (dev *Device) Resume() error {
+	return dev.Call(dev.prefix+".Suspend", 0, false).Err
+}
+
+//
+//-------------------------------------------------------------[ DEVICE PORT ]--
+
+// PortAvailability defines whether a device port is available (ex: a jack
+// plugged in).
+//
+type PortAvailability uint32
+
+// Port availability states.
+//
+const (
+	PortAvailableUnknown PortAvailability = iota // The availability can't be detected.
+	PortAvailableNo                              // The port isn't available (unplugged).
+	PortAvailableYes                             // The port is available (plugged).
+)
+
+// DevicePort controls a pulseaudio device port.
+//
+// Properties list:
+//   Uint32
+//     Index         The port index.
+//     Priority      The higher the priority, the better the port.
+//     Available     Whether the port is available. See PortAvailability
+//                   (since interface revision 1).
+//
+//   String
+//     Name          The port name.
+//     Description   The port human readable description.
+//
+func (pulse *Client) DevicePort(path dbus.ObjectPath) *DevicePort {
+	return &DevicePort{pulse.newObject(DbusInterface+".DevicePort", path)}
+}
+
+// DevicePort is a typed access to a pulseaudio device port.
+// See Client.DevicePort for the properties list.
+//
+type DevicePort struct {
+	*Object
+}
+
+// Name returns the port name.
+//
+func (port *DevicePort) Name() (string, error) {
+	return port.String("Name")
+}
+
+// Description returns the port human readable description.
+//
+func (port *DevicePort) Description() (string, error) {
+	return port.String("Description")
+}
+
+// Available returns whether the port is available.
+//
+func (port *DevicePort) Available() (PortAvailability, error) {
+	avail, e := port.Uint32("Available")
+	return PortAvailability(avail), e
+}
+
+//
+//------------------------------------------------------------[ DEVICE STATE ]--
+
+// DeviceState defines the state of a device.
+//
+type DeviceState uint32
+
+// Device states.
+//
+const (
+	StateRunning   DeviceState = iota // The device is in use.
+	StateIdle                         // The device has no active streams.
+	StateSuspended                    // The device is suspended.
+)
+
+// String returns a human readable device state.
+//
+func (state DeviceState) String() string {
+	switch state {
+	case StateRunning:
+		return "running"
+	case StateIdle:
+		return "idle"
+	case StateSuspended:
+		return "suspended"
+	}
+	return "DeviceState(" + strconv.Itoa(int(state)) + ")"
+}
+
+// StateWatcher keeps track of the devices states through the
+// Device.StateUpdated signals. Register it to the client to start watching.
+//
+//   watcher := pulseaudio.NewStateWatcher(func(path dbus.ObjectPath, state pulseaudio.DeviceState) {
+//   	log.Println("device", path, state)
+//   })
+//   pulse.Register(watcher)
+//
+type StateWatcher struct {
+	onChange func(dbus.ObjectPath, DeviceState)
+	states   map[dbus.ObjectPath]DeviceState
+	mu       sync.RWMutex
+}
+
+// NewStateWatcher creates a device state watcher. onChange is optional.
+//
+func NewStateWatcher(onChange func(dbus.ObjectPath, DeviceState)) *StateWatcher {
+	return &StateWatcher{
+		onChange: onChange,
+		states:   make(map[dbus.ObjectPath]DeviceState),
+	}
+}
+
+// State returns the last known state of a device, and whether it was found.
+//
+func (w *StateWatcher) State(path dbus.ObjectPath) (DeviceState, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	state, ok := w.states[path]
+	return state, ok
+}
+
+// DeviceStateUpdated is called when the state has changed on a device.
+//
+func (w *StateWatcher) DeviceStateUpdated(path dbus.ObjectPath, state DeviceState) {
+	w.mu.Lock()
+	old, known := w.states[path]
+	w.states[path] = state
+	w.mu.Unlock()
+
+	if w.onChange != nil && (!known || old != state) {
+		w.onChange(path, state)
+	}
+}
+
+// SinkRemovedPath is called when a sink is removed. Forget its state.
+//
+func (w *StateWatcher) SinkRemovedPath(path dbus.ObjectPath) {
+	w.mu.Lock()
+	delete(w.states, path)
+	w.mu.Unlock()
+}
+
+// SourceRemoved is called when a source is removed. Forget its state.
+//
+func (w *StateWatcher) SourceRemoved(path dbus.ObjectPath) {
+	w.SinkRemovedPath(path)
+}
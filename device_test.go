@@ -0,0 +1,112 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"reflect"
+	"testing"
+)
+
+func TestDeviceState(t *testing.T) {
+	for state, want := range map[pulseaudio.DeviceState]string{
+		pulseaudio.StateRunning:   "running",
+		pulseaudio.StateIdle:      "idle",
+		pulseaudio.StateSuspended: "suspended",
+		7:                         "DeviceState(7)",
+	} {
+		if got := state.String(); got != want {
+			t.Errorf("DeviceState(%d): got %q, want %q", state, got, want)
+		}
+	}
+}
+
+func TestStateWatcher(t *testing.T) {
+	const sink = dbus.ObjectPath("/org/pulseaudio/core1/sink0")
+	var changes []pulseaudio.DeviceState
+	watcher := pulseaudio.NewStateWatcher(func(path dbus.ObjectPath, state pulseaudio.DeviceState) {
+		changes = append(changes, state)
+	})
+
+	if _, ok := watcher.State(sink); ok {
+		t.Fatal("state found before any update")
+	}
+
+	watcher.DeviceStateUpdated(sink, pulseaudio.StateRunning)
+	watcher.DeviceStateUpdated(sink, pulseaudio.StateRunning) // no change.
+	watcher.DeviceStateUpdated(sink, pulseaudio.StateIdle)
+
+	state, ok := watcher.State(sink)
+	if !ok || state != pulseaudio.StateIdle {
+		t.Errorf("state: got %s, %t, want idle", state, ok)
+	}
+	if len(changes) != 2 {
+		t.Errorf("changes: got %v, want [running idle]", changes)
+	}
+
+	watcher.SinkRemovedPath(sink)
+	if _, ok := watcher.State(sink); ok {
+		t.Error("state found after sink removed")
+	}
+
+	const source = dbus.ObjectPath("/org/pulseaudio/core1/source0")
+	watcher.DeviceStateUpdated(source, pulseaudio.StateIdle)
+	watcher.SourceRemoved(source)
+	if _, ok := watcher.State(source); ok {
+		t.Error("state found after source removed")
+	}
+}
+
+type sinkWatcher struct{ events []string }
+
+func (w *sinkWatcher) FallbackSinkUpdated(path dbus.ObjectPath) {
+	w.events = append(w.events, "fallback signal "+string(path))
+}
+
+func (w *sinkWatcher) FallbackSinkUpdatedPath(path dbus.ObjectPath) {
+	w.events = append(w.events, "fallback "+string(path))
+}
+
+func (w *sinkWatcher) NewSink(path dbus.ObjectPath) {
+	w.events = append(w.events, "new signal "+string(path))
+}
+
+func (w *sinkWatcher) NewSinkPath(path dbus.ObjectPath) {
+	w.events = append(w.events, "new "+string(path))
+}
+
+func (w *sinkWatcher) SinkRemoved(path dbus.ObjectPath) {
+	w.events = append(w.events, "removed signal "+string(path))
+}
+
+func (w *sinkWatcher) SinkRemovedPath(path dbus.ObjectPath) {
+	w.events = append(w.events, "removed "+string(path))
+}
+
+// Core signals are emitted by the core object, the sink is the signal data.
+// The sink callbacks without Path suffix receive the signal path, as before.
+func TestSinkSignalsPath(t *testing.T) {
+	pulse := pulseaudio.NewReplayClient()
+	w := &sinkWatcher{}
+	pulse.Register(w)
+
+	for _, name := range []string{"NewSink", "FallbackSinkUpdated", "SinkRemoved"} {
+		pulse.DispatchSignal(&dbus.Signal{
+			Name: pulseaudio.DbusInterface + "." + name,
+			Path: pulseaudio.DbusPath,
+			Body: []interface{}{dbus.ObjectPath("/org/pulseaudio/core1/sink1")},
+		})
+	}
+	want := []string{
+		"new signal /org/pulseaudio/core1",
+		"new /org/pulseaudio/core1/sink1",
+		"fallback signal /org/pulseaudio/core1",
+		"fallback /org/pulseaudio/core1/sink1",
+		"removed signal /org/pulseaudio/core1",
+		"removed /org/pulseaudio/core1/sink1",
+	}
+	if !reflect.DeepEqual(w.events, want) {
+		t.Errorf("got %v, want %v", w.events, want)
+	}
+}
@@ -65,6 +65,21 @@ Register your object and start the listening loop:
 
 	pulse.Listen()
 
+For a single event, a typed handler can be subscribed instead:
+	pulseaudio.Subscribe(pulse, func(ev pulseaudio.DeviceVolumeUpdated) {
+		log.Println("device volume", ev.Path, ev.Volume)
+	})
+
+Events are declared in the PulseEvents table (and extensions tables), with
+their payload type and decoder. Register and Subscribe both use it.
+
+Typed bindings for every interface, generated from the introspection XML, are
+provided by the pulsedbus package. The events and their tables are generated
+from the same files.
+
+Features varying between servers (extensions, optional properties) can be
+checked with Client.Capabilities and Object.Introspect.
+
 Get properties
 
 There are way too many properties to have a dedicated method for each of them.
@@ -72,21 +87,32 @@ There are way too many properties to have a dedicated method for each of them.
     Core
     Device
     Stream
+    Client
+    Card
+    Sample
+    Module
   Name    Name of the property
   Type    Type of the property.
     bool          Bool
     uint32        Uint32
     uint64        Uint64
+    float64       Float64
     string        String
     ObjectPath    Path
     []uint32      ListUint32
+    []float64     ListFloat64
     []string      ListString
     []ObjectPath  ListPath
+    map           MapString, PropertyList
 
 First you need to get the object implementing the property you need.
 Then you will have to call the method matching the type of returned data for the
 property you want to get. See the example.
 
+For other types, the generic Get method converts the Dbus value to any
+compatible Go type given as pointer (numerics, slices, maps and structs).
+See StoreValue for the details.
+
 Set properties
 
 Properties with the tag RW can also be set.
@@ -182,7 +208,13 @@ import "github.com/godbus/dbus"
 //     Clients            All currently connected clients.
 //
 func (pulse *Client) Core() *Object {
-	return NewObject(pulse.conn, DbusInterface, DbusPath)
+	return pulse.newObject(DbusInterface, DbusPath)
+}
+
+// Object returns a Dbus object of the server, for the given interface.
+//
+func (pulse *Client) Object(interf string, path dbus.ObjectPath) *Object {
+	return pulse.newObject(interf, path)
 }
 
 // Device controls a pulseaudio device.
@@ -226,7 +258,7 @@ func (pulse *Client) Core() *Object {
 //     !VolumeSteps   If the device doesn't support arbitrary volume values, this property
 //                    tells the number of possible volume values.
 //                    Otherwise this property has value 65537.
-//     State          The current state of the device.
+//     State          The current state of the device. See DeviceState.
 //
 //   Uint64
 //     !ConfiguredLatency  The latency in microseconds that device has been configured to.
@@ -264,7 +296,7 @@ func (pulse *Client) Core() *Object {
 //     !PropertyList       The device's property list.
 //
 func (pulse *Client) Device(sink dbus.ObjectPath) *Object {
-	return NewObject(pulse.conn, DbusInterface+".Device", sink)
+	return pulse.newObject(DbusInterface+".Device", sink)
 }
 
 // Stream controls a pulseaudio stream.
@@ -308,11 +340,38 @@ func (pulse *Client) Device(sink dbus.ObjectPath) *Object {
 //    !PropertyList   The stream's property list.
 //
 func (pulse *Client) Stream(sink dbus.ObjectPath) *Object {
-	return NewObject(pulse.conn, DbusInterface+".Stream", sink)
+	return pulse.newObject(DbusInterface+".Stream", sink)
 }
 
-// Client controls a pulseaudio client.
+// Client controls a pulseaudio client (an application connected to the server).
+//
+// Methods list:
+//   Kill               Kills the client.
+//   UpdateProperties   Updates the client property list. See UpdateProperties.
+//     PropertyList:      Properties to set.
+//     UpdateMode:        How to merge with the current property list.
+//   RemoveProperties   Removes keys from the client property list. See RemoveProperties.
+//     []string:          Keys to remove.
+//
+// Properties list:
+//   Uint32
+//     Index         The client index.
+//
+//   String
+//     Driver        The driver that implements the client object. This is usually
+//                   expressed as a source code file name, for example "protocol-native.c".
+//
+//   ObjectPath
+//     !OwnerModule  The module that owns this client. It's not guaranteed that any
+//                   module claims ownership; in such case this property does not exist.
+//
+//   ListPath
+//     !PlaybackStreams  All playback streams whose client is this client.
+//     !RecordStreams    All record streams whose client is this client.
+//
+//   MapString
+//     !PropertyList   The client's property list.
 //
 func (pulse *Client) Client(sink dbus.ObjectPath) *Object {
-	return NewObject(pulse.conn, DbusInterface+".Client", sink)
+	return pulse.newObject(DbusInterface+".Client", sink)
 }
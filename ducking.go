@@ -0,0 +1,344 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// maxPendingVolumes is the number of volumes set by the ducker remembered to
+// recognize their signals.
+const maxPendingVolumes = 64
+
+// Ducker is an opt-in policy that lowers the volume of playback streams while
+// a stream with a trigger role is playing, like a VoIP call, and restores
+// them when the last trigger stream ends. It works like the server
+// module-role-ducking, but is controlled by the application.
+//
+// Roles are read from the media.role property of streams. Volume changes are
+// applied with a linear ramp to avoid clicks. The ramps share the client ramp
+// registry: a Stream.RampVolume on a ducked stream cancels the policy ramp.
+//
+// If the user changes the volume of a ducked stream, the new volume is kept:
+// the stream is released from the policy and won't be restored.
+//
+//   duck := pulseaudio.NewDucker(pulse)
+//   duck.DuckRoles = []string{"music", "video"}
+//   duck.Level = 0.3
+//   duck.Start()
+//   defer duck.Stop()
+//
+type Ducker struct {
+	TriggerRoles []string      // Roles of streams starting the ducking. Default phone.
+	DuckRoles    []string      // Roles of ducked streams. Optional, default all non trigger streams.
+	Level        float64       // Volume ratio applied to ducked streams. Default 0.2 (20%).
+	Ramp         time.Duration // Duration of volume changes. Default 500ms.
+	OnError      func(error)   // Error logger callback. Optional.
+
+	pulse    *Client
+	roles    map[dbus.ObjectPath]string        // Role by known playback stream.
+	triggers map[dbus.ObjectPath]bool          // Trigger streams playing.
+	ducked   map[dbus.ObjectPath]*duckedStream // Streams managed by the policy.
+	mu       sync.Mutex
+}
+
+// duckedStream is the state of a stream ducked by the policy.
+//
+type duckedStream struct {
+	original []uint32           // Volume to restore.
+	current  []uint32           // Last volume set.
+	pending  [][]uint32         // Volumes set, not signaled yet.
+	stop     context.CancelFunc // Cancels the running ramp.
+}
+
+// NewDucker creates a ducking policy with default settings.
+//
+func NewDucker(pulse *Client) *Ducker {
+	return &Ducker{
+		TriggerRoles: []string{"phone"},
+		Level:        0.2,
+		Ramp:         500 * time.Millisecond,
+		pulse:        pulse,
+		roles:        make(map[dbus.ObjectPath]string),
+		triggers:     make(map[dbus.ObjectPath]bool),
+		ducked:       make(map[dbus.ObjectPath]*duckedStream),
+	}
+}
+
+// Start registers the policy to the client events and checks streams already
+// playing.
+//
+func (d *Ducker) Start() (errs []error) {
+	errs = d.pulse.Register(d)
+
+	streams, e := d.pulse.Core().ListPath("PlaybackStreams")
+	if e != nil {
+		return append(errs, e)
+	}
+	for _, stream := range streams {
+		d.NewPlaybackStream(stream)
+	}
+	return errs
+}
+
+// Stop unregisters the policy and restores the ducked streams immediately.
+//
+func (d *Ducker) Stop() (errs []error) {
+	errs = d.pulse.Unregister(d)
+
+	d.mu.Lock()
+	restore := make(map[dbus.ObjectPath][]uint32)
+	for path, st := range d.ducked {
+		st.cancel()
+		restore[path] = st.original
+	}
+	d.roles = make(map[dbus.ObjectPath]string)
+	d.ducked = make(map[dbus.ObjectPath]*duckedStream)
+	d.triggers = make(map[dbus.ObjectPath]bool)
+	d.mu.Unlock()
+
+	for path, vol := range restore {
+		if e := d.pulse.Stream(path).Set("Volume", vol); e != nil {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+// Active returns whether a trigger stream is playing.
+//
+func (d *Ducker) Active() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.triggers) > 0
+}
+
+// NewPlaybackStream is called when a playback stream is added.
+// Starts the ducking for a trigger stream, or ducks the new stream if needed.
+//
+func (d *Ducker) NewPlaybackStream(path dbus.ObjectPath) {
+	props, e := d.pulse.TypedStream(path).PropertyList()
+	if e != nil {
+		d.logError(e)
+		return
+	}
+	role := props.MediaRole()
+
+	d.mu.Lock()
+	d.roles[path] = role
+	var toDuck []dbus.ObjectPath
+	switch {
+	case containsString(d.TriggerRoles, role):
+		d.triggers[path] = true
+		if len(d.triggers) == 1 {
+			for stream, role := range d.roles {
+				if d.isDuckable(role) {
+					toDuck = append(toDuck, stream)
+				}
+			}
+		}
+
+	case len(d.triggers) > 0 && d.isDuckable(role):
+		toDuck = append(toDuck, path)
+	}
+	d.mu.Unlock()
+
+	for _, stream := range toDuck {
+		d.duck(stream)
+	}
+}
+
+// PlaybackStreamRemoved is called when a playback stream is removed.
+// Restores the ducked streams when the last trigger stream is removed.
+//
+func (d *Ducker) PlaybackStreamRemoved(path dbus.ObjectPath) {
+	d.mu.Lock()
+	delete(d.roles, path)
+	if st, ok := d.ducked[path]; ok {
+		st.cancel()
+		delete(d.ducked, path)
+	}
+	if d.triggers[path] {
+		delete(d.triggers, path)
+		if len(d.triggers) == 0 {
+			for stream, st := range d.ducked {
+				d.startRamp(stream, st, st.original, true)
+			}
+		}
+	}
+	d.mu.Unlock()
+}
+
+// StreamVolumeUpdated is called when the volume has changed on a stream.
+// A change not made by the policy releases the stream.
+//
+func (d *Ducker) StreamVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st, ok := d.ducked[path]
+	if !ok {
+		return
+	}
+	for i, vol := range st.pending {
+		if equalUint32(vol, values) {
+			st.pending = st.pending[i+1:]
+			return
+		}
+	}
+	st.cancel()
+	delete(d.ducked, path)
+}
+
+// duck lowers the volume of a stream.
+//
+func (d *Ducker) duck(path dbus.ObjectPath) {
+	d.mu.Lock()
+	st, ok := d.ducked[path]
+	d.mu.Unlock()
+
+	if !ok {
+		vol, e := d.pulse.Stream(path).ListUint32("Volume")
+		if e != nil {
+			d.logError(e)
+			return
+		}
+		st = &duckedStream{original: vol, current: vol}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, known := d.roles[path]; !known || len(d.triggers) == 0 {
+		return // Removed, or ducking ended meanwhile.
+	}
+	if current, ok := d.ducked[path]; ok {
+		st = current // Restore ramp in progress, or ducked meanwhile.
+	}
+	d.ducked[path] = st
+	d.startRamp(path, st, ScaleVolume(st.original, d.Level), false)
+}
+
+// startRamp starts a volume ramp on a stream, cancelling the previous one.
+// The stream is released from the policy at the end of the ramp if release is
+// set. Must be locked.
+//
+func (d *Ducker) startRamp(path dbus.ObjectPath, st *duckedStream, to []uint32, release bool) {
+	st.cancel()
+	ctx, stop := context.WithCancel(context.Background())
+	st.stop = stop
+	from := st.current
+
+	go func() {
+		defer stop()
+		e := d.pulse.ramp(ctx, path, from, to, d.Ramp, RampLinear, 0, func(vol []uint32) error {
+			d.mu.Lock()
+			if ctx.Err() != nil {
+				d.mu.Unlock()
+				return ctx.Err()
+			}
+			st.current = vol
+			st.pending = append(st.pending, vol)
+			if len(st.pending) > maxPendingVolumes { // Some changes may not be signaled.
+				st.pending = st.pending[len(st.pending)-maxPendingVolumes:]
+			}
+			d.mu.Unlock()
+			return d.pulse.Stream(path).Set("Volume", vol)
+		})
+
+		switch {
+		case e == nil && release:
+			d.mu.Lock()
+			if d.ducked[path] == st {
+				delete(d.ducked, path)
+			}
+			d.mu.Unlock()
+
+		case e != nil && e != context.Canceled && e != ErrRampCanceled:
+			d.logError(e)
+		}
+	}()
+}
+
+// isDuckable returns whether a stream with the role must be ducked.
+//
+func (d *Ducker) isDuckable(role string) bool {
+	if containsString(d.TriggerRoles, role) {
+		return false
+	}
+	return len(d.DuckRoles) == 0 || containsString(d.DuckRoles, role)
+}
+
+// logError forwards an error to the OnError callback if any.
+//
+func (d *Ducker) logError(e error) {
+	if e != nil && d.OnError != nil {
+		d.OnError(e)
+	}
+}
+
+// cancel stops the running ramp if any. Must be locked.
+//
+func (st *duckedStream) cancel() {
+	if st.stop != nil {
+		st.stop()
+		st.stop = nil
+	}
+}
+
+// ScaleVolume returns the channel volumes multiplied by ratio.
+//
+func ScaleVolume(vol []uint32, ratio float64) []uint32 {
+	out := make([]uint32, len(vol))
+	for i, v := range vol {
+		out[i] = clampVolume(float64(v) * ratio)
+	}
+	return out
+}
+
+// interpolateVolume returns the channel volumes at pos (0 to 1) between from
+// and to. Both lists must have the same length.
+//
+func interpolateVolume(from, to []uint32, pos float64) []uint32 {
+	out := make([]uint32, len(to))
+	for i := range to {
+		start := float64(to[i])
+		if i < len(from) {
+			start = float64(from[i])
+		}
+		out[i] = clampVolume(start + (float64(to[i])-start)*pos)
+	}
+	return out
+}
+
+func clampVolume(v float64) uint32 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= math.MaxUint32:
+		return math.MaxUint32
+	}
+	return uint32(math.Round(v))
+}
+
+func containsString(list []string, str string) bool {
+	for _, test := range list {
+		if test == str {
+			return true
+		}
+	}
+	return false
+}
+
+func equalUint32(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
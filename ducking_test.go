@@ -0,0 +1,125 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScaleVolume(t *testing.T) {
+	for _, test := range []struct {
+		vol   []uint32
+		ratio float64
+		want  []uint32
+	}{
+		{[]uint32{65536, 32768}, 0.2, []uint32{13107, 6554}},
+		{[]uint32{65536}, 1, []uint32{65536}},
+		{[]uint32{65536}, 0, []uint32{0}},
+		{[]uint32{65536}, -1, []uint32{0}},
+		{[]uint32{}, 0.5, []uint32{}},
+	} {
+		got := pulseaudio.ScaleVolume(test.vol, test.ratio)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("scale volume %v by %v: got %v, want %v", test.vol, test.ratio, got, test.want)
+		}
+	}
+}
+
+// newDuckTest starts a ducker on a fake server with two music streams.
+func newDuckTest(t *testing.T) (*pulseaudio.Client, *fakeServer, *pulseaudio.Ducker) {
+	pulse := pulseaudio.NewReplayClient()
+	fs := newFakeServer(pulse)
+	fs.set(pulseaudio.DbusPath, "PlaybackStreams", []dbus.ObjectPath{"/stream0", "/stream1"})
+	for _, path := range []dbus.ObjectPath{"/stream0", "/stream1"} {
+		fs.set(path, "PropertyList", map[string][]byte{pulseaudio.PropMediaRole: []byte("music\x00")})
+		fs.set(path, "Volume", []uint32{65536, 32768})
+	}
+	fs.set("/phone", "PropertyList", map[string][]byte{pulseaudio.PropMediaRole: []byte("phone\x00")})
+
+	duck := pulseaudio.NewDucker(pulse)
+	duck.Ramp = 0
+	duck.OnError = func(e error) { t.Error("ducker:", e) }
+	if errs := duck.Start(); len(errs) > 0 {
+		t.Fatal("start:", errs)
+	}
+	return pulse, fs, duck
+}
+
+func dispatchStream(pulse *pulseaudio.Client, signal string, path dbus.ObjectPath) {
+	pulse.DispatchSignal(&dbus.Signal{Name: pulseaudio.DbusInterface + "." + signal, Path: pulseaudio.DbusPath, Body: []interface{}{path}})
+}
+
+func dispatchVolume(pulse *pulseaudio.Client, path dbus.ObjectPath, vol []uint32) {
+	pulse.DispatchSignal(&dbus.Signal{Name: pulseaudio.DbusInterface + ".Stream.VolumeUpdated", Path: path, Body: []interface{}{vol}})
+}
+
+// waitVolume waits for the ramps to set the volume of a stream.
+func waitVolume(t *testing.T, fs *fakeServer, path dbus.ObjectPath, want []uint32) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !reflect.DeepEqual(fs.get(path, "Volume"), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s volume: got %v, want %v", path, fs.get(path, "Volume"), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDucker(t *testing.T) {
+	pulse, fs, duck := newDuckTest(t)
+	defer duck.Stop()
+
+	dispatchStream(pulse, "NewPlaybackStream", "/phone")
+	if !duck.Active() {
+		t.Error("want the ducking active")
+	}
+	waitVolume(t, fs, "/stream0", []uint32{13107, 6554})
+	waitVolume(t, fs, "/stream1", []uint32{13107, 6554})
+
+	// The signal of a volume set by the policy keeps the stream ducked, a
+	// volume changed by the user releases it.
+	dispatchVolume(pulse, "/stream0", []uint32{13107, 6554})
+	fs.set("/stream1", "Volume", []uint32{50000, 50000})
+	dispatchVolume(pulse, "/stream1", []uint32{50000, 50000})
+
+	// The end of the trigger stream restores the ducked streams only.
+	dispatchStream(pulse, "PlaybackStreamRemoved", "/phone")
+	if duck.Active() {
+		t.Error("want the ducking inactive")
+	}
+	waitVolume(t, fs, "/stream0", []uint32{65536, 32768})
+	time.Sleep(50 * time.Millisecond)
+	if got := fs.get("/stream1", "Volume"); !reflect.DeepEqual(got, []uint32{50000, 50000}) {
+		t.Errorf("released stream volume: got %v, want the user volume", got)
+	}
+}
+
+func TestDuckerStop(t *testing.T) {
+	pulse, fs, duck := newDuckTest(t)
+
+	dispatchStream(pulse, "NewPlaybackStream", "/phone")
+	waitVolume(t, fs, "/stream0", []uint32{13107, 6554})
+	waitVolume(t, fs, "/stream1", []uint32{13107, 6554})
+	fs.takeCalls()
+
+	if errs := duck.Stop(); len(errs) > 0 {
+		t.Error("stop:", errs)
+	}
+	for _, path := range []dbus.ObjectPath{"/stream0", "/stream1"} {
+		if got := fs.get(path, "Volume"); !reflect.DeepEqual(got, []uint32{65536, 32768}) {
+			t.Errorf("%s volume after stop: got %v, want %v", path, got, []uint32{65536, 32768})
+		}
+	}
+
+	// Stopped, a new trigger stream is ignored.
+	fs.takeCalls()
+	dispatchStream(pulse, "NewPlaybackStream", "/phone")
+	time.Sleep(50 * time.Millisecond)
+	if calls := fs.takeCalls(); len(calls) != 0 {
+		t.Errorf("stopped: got calls %v, want none", calls)
+	}
+}
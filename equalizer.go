@@ -0,0 +1,288 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"fmt"
+	"math"
+)
+
+// Equalizer extension Dbus objects paths.
+// Requires module-equalizer-sink.
+//
+const (
+	EqualizerManagerInterface = "org.PulseAudio.Ext.Equalizing1.Manager"
+	EqualizerInterface        = "org.PulseAudio.Ext.Equalizing1.Equalizer"
+	EqualizerManagerPath      = "/org/pulseaudio/equalizing1"
+)
+
+// EqualizerManager controls the equalizer sinks and their shared presets.
+//
+// Methods list:
+//   RemoveProfile    Removes a saved profile.
+//     string:          Profile name.
+//
+// Properties list:
+//   Uint32
+//     !InterfaceRevision  The version of the extension interface.
+//
+//   ListString
+//     Profiles            All saved profiles names.
+//
+//   ListPath
+//     !EqualizedSinks     All equalizer sinks.
+//
+func (pulse *Client) EqualizerManager() *EqualizerManager {
+	return &EqualizerManager{pulse.newObject(EqualizerManagerInterface, EqualizerManagerPath), pulse}
+}
+
+// Equalizer controls the equalizer of a sink. The path is the sink path.
+//
+// Channels are referenced by index. Using the channels count (NChannels) as
+// channel index targets all channels at once.
+//
+// Methods list:
+//   FilterAtPoints   Gets the filter coefficients at the given points.
+//     uint32:          Channel.
+//     []uint32:        Points, in the filter sample rate space.
+//     out: []float64:  Coefficients.
+//     out: float64:    Preamp.
+//   SeedFilter       Sets the filter, interpolated from the given points.
+//     uint32:          Channel.
+//     []uint32:        Points, in the filter sample rate space.
+//     []float64:       Coefficients.
+//     float64:         Preamp.
+//   SaveProfile      Saves the channel filter as a named profile.
+//     uint32:          Channel.
+//     string:          Profile name.
+//   LoadProfile      Loads a named profile on the channel.
+//     uint32:          Channel.
+//     string:          Profile name.
+//   BaseProfile      Gets the name of the last profile loaded on the channel.
+//     uint32:          Channel.
+//     out: string:     Profile name.
+//   SaveState        Saves the current filters as the default state.
+//
+// Properties list:
+//   Uint32
+//     !InterfaceRevision  The version of the extension interface.
+//     !SampleRate         The sample rate of the sink.
+//     !FilterSampleRate   The sample rate of the filter (points space).
+//     !FilterLength       The number of coefficients of the filter.
+//     !NChannels          The number of channels.
+//
+func (pulse *Client) Equalizer(sink dbus.ObjectPath) *Equalizer {
+	return &Equalizer{pulse.newObject(EqualizerInterface, sink)}
+}
+
+// EqualizerManager is a typed access to the equalizer extension manager.
+// See Client.EqualizerManager for the properties list.
+//
+type EqualizerManager struct {
+	*Object
+	pulse *Client
+}
+
+// Sinks returns the equalizers of all equalizer sinks.
+//
+func (manager *EqualizerManager) Sinks() ([]*Equalizer, error) {
+	paths, e := manager.ListPath("EqualizedSinks")
+	if e != nil {
+		return nil, e
+	}
+	eqs := make([]*Equalizer, len(paths))
+	for i, path := range paths {
+		eqs[i] = manager.pulse.Equalizer(path)
+	}
+	return eqs, nil
+}
+
+// Profiles returns the names of saved profiles.
+//
+func (manager *EqualizerManager) Profiles() ([]string, error) {
+	return manager.ListString("Profiles")
+}
+
+// RemoveProfile removes a saved profile.
+//
+func (manager *EqualizerManager) RemoveProfile(name string) error {
+	return manager.Call(manager.prefix+".RemoveProfile", 0, name).Err
+}
+
+//
+//---------------------------------------------------------------[ EQUALIZER ]--
+
+// EqualizerBand is the gain of the equalizer at a given frequency.
+//
+type EqualizerBand struct {
+	Frequency float64 // Frequency in Hz.
+	Gain      float64 // Linear coefficient, 1 means unchanged. See GainToDB.
+}
+
+// Equalizer is a typed access to the equalizer of a sink.
+// See Client.Equalizer for the properties list.
+//
+type Equalizer struct {
+	*Object
+}
+
+// SampleRate returns the sample rate of the sink.
+//
+func (eq *Equalizer) SampleRate() (uint32, error) {
+	return eq.Uint32("SampleRate")
+}
+
+// FilterSampleRate returns the sample rate of the filter.
+//
+func (eq *Equalizer) FilterSampleRate() (uint32, error) {
+	return eq.Uint32("FilterSampleRate")
+}
+
+// FilterLength returns the number of coefficients of the filter.
+//
+func (eq *Equalizer) FilterLength() (uint32, error) {
+	return eq.Uint32("FilterLength")
+}
+
+// Channels returns the number of channels. This value can also be used as
+// channel index to target all channels.
+//
+func (eq *Equalizer) Channels() (uint32, error) {
+	return eq.Uint32("NChannels")
+}
+
+// FilterAtPoints returns the filter coefficients and preamp of the channel
+// at the given points, in the filter sample rate space.
+//
+func (eq *Equalizer) FilterAtPoints(channel uint32, xs []uint32) (ys []float64, preamp float64, e error) {
+	e = eq.Call(eq.prefix+".FilterAtPoints", 0, channel, xs).Store(&ys, &preamp)
+	return ys, preamp, e
+}
+
+// SeedFilter sets the filter coefficients and preamp of the channel,
+// interpolated from the given points in the filter sample rate space.
+//
+func (eq *Equalizer) SeedFilter(channel uint32, xs []uint32, ys []float64, preamp float64) error {
+	return eq.Call(eq.prefix+".SeedFilter", 0, channel, xs, ys, preamp).Err
+}
+
+// Bands returns the gain of the channel at the given frequencies (in Hz),
+// and the preamp.
+//
+func (eq *Equalizer) Bands(channel uint32, freqs []float64) ([]EqualizerBand, float64, error) {
+	xs, e := eq.points(freqs)
+	if e != nil {
+		return nil, 0, e
+	}
+	ys, preamp, e := eq.FilterAtPoints(channel, xs)
+	if e != nil {
+		return nil, 0, e
+	}
+	if len(ys) != len(freqs) {
+		return nil, 0, fmt.Errorf("equalizer: got %d coefficients for %d bands", len(ys), len(freqs))
+	}
+	bands := make([]EqualizerBand, len(freqs))
+	for i, freq := range freqs {
+		bands[i] = EqualizerBand{Frequency: freq, Gain: ys[i]}
+	}
+	return bands, preamp, nil
+}
+
+// SetBands sets the filter of the channel from a list of bands sorted by
+// frequency, and the preamp.
+//
+// The filter requires points at 0 Hz and at the Nyquist frequency (half the
+// sample rate): they are added with the gain of the nearest band if missing.
+//
+func (eq *Equalizer) SetBands(channel uint32, bands []EqualizerBand, preamp float64) error {
+	if len(bands) == 0 {
+		return fmt.Errorf("equalizer: no bands to set")
+	}
+	rate, e := eq.SampleRate()
+	if e != nil {
+		return e
+	}
+	nyquist := float64(rate) / 2
+	if bands[0].Frequency > 0 {
+		bands = append([]EqualizerBand{{0, bands[0].Gain}}, bands...)
+	}
+	if last := bands[len(bands)-1]; last.Frequency < nyquist {
+		bands = append(bands, EqualizerBand{nyquist, last.Gain})
+	}
+
+	freqs := make([]float64, len(bands))
+	ys := make([]float64, len(bands))
+	for i, band := range bands {
+		freqs[i] = band.Frequency
+		ys[i] = band.Gain
+	}
+	xs, e := eq.points(freqs)
+	if e != nil {
+		return e
+	}
+	return eq.SeedFilter(channel, xs, ys, preamp)
+}
+
+// SaveProfile saves the channel filter as a named profile.
+//
+func (eq *Equalizer) SaveProfile(channel uint32, name string) error {
+	return eq.Call(eq.prefix+".SaveProfile", 0, channel, name).Err
+}
+
+// LoadProfile loads a named profile on the channel.
+//
+func (eq *Equalizer) LoadProfile(channel uint32, name string) error {
+	return eq.Call(eq.prefix+".LoadProfile", 0, channel, name).Err
+}
+
+// BaseProfile returns the name of the last profile loaded on the channel.
+//
+func (eq *Equalizer) BaseProfile(channel uint32) (name string, e error) {
+	e = eq.Call(eq.prefix+".BaseProfile", 0, channel).Store(&name)
+	return name, e
+}
+
+// SaveState saves the current filters as the default state.
+//
+func (eq *Equalizer) SaveState() error {
+	return eq.Call(eq.prefix+".SaveState", 0).Err
+}
+
+// points converts frequencies in Hz to points in the filter sample rate space.
+//
+func (eq *Equalizer) points(freqs []float64) ([]uint32, error) {
+	rate, e := eq.SampleRate()
+	if e != nil {
+		return nil, e
+	}
+	filterRate, e := eq.FilterSampleRate()
+	if e != nil {
+		return nil, e
+	}
+	return FrequencyPoints(freqs, rate, filterRate), nil
+}
+
+// FrequencyPoints converts frequencies in Hz to equalizer points, in the
+// filter sample rate space. Frequencies are clamped to the Nyquist frequency.
+//
+func FrequencyPoints(freqs []float64, sampleRate, filterRate uint32) []uint32 {
+	xs := make([]uint32, len(freqs))
+	nyquist := float64(sampleRate) / 2
+	for i, freq := range freqs {
+		freq = math.Max(0, math.Min(freq, nyquist))
+		xs[i] = uint32(math.Round(freq * float64(filterRate) / float64(sampleRate)))
+	}
+	return xs
+}
+
+// GainToDB converts a linear gain coefficient to decibels.
+//
+func GainToDB(gain float64) float64 {
+	return 20 * math.Log10(gain)
+}
+
+// DBToGain converts decibels to a linear gain coefficient.
+//
+func DBToGain(db float64) float64 {
+	return math.Pow(10, db/20)
+}
@@ -0,0 +1,53 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFrequencyPoints(t *testing.T) {
+	got := pulseaudio.FrequencyPoints([]float64{-10, 0, 1000, 22050, 30000}, 44100, 32000)
+	want := []uint32{0, 0, 726, 16000, 16000}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("frequency points: got %v, want %v", got, want)
+	}
+
+	for _, db := range []float64{-12, 0, 6} {
+		if got := pulseaudio.GainToDB(pulseaudio.DBToGain(db)); math.Abs(got-db) > 1e-9 {
+			t.Errorf("gain round trip %g dB: got %g", db, got)
+		}
+	}
+}
+
+type equalizerClient struct {
+	profiles int
+	filters  []dbus.ObjectPath
+}
+
+func (cl *equalizerClient) EqualizerProfilesChanged() { cl.profiles++ }
+
+func (cl *equalizerClient) EqualizerFilterChanged(path dbus.ObjectPath) {
+	cl.filters = append(cl.filters, path)
+}
+
+func TestEqualizerHooks(t *testing.T) {
+	hooker := pulseaudio.NewHooker()
+	hooker.AddEvents(pulseaudio.EqualizerEvents)
+
+	client := &equalizerClient{}
+	if tolisten := hooker.Register(client); len(tolisten) != 2 {
+		t.Fatalf("register: got %v, want 2 events to listen", tolisten)
+	}
+
+	hooker.Call(pulseaudio.EqualizerManagerInterface+".ProfilesChanged", &dbus.Signal{Path: pulseaudio.EqualizerManagerPath})
+	hooker.Call(pulseaudio.EqualizerInterface+".FilterChanged", &dbus.Signal{Path: "/org/pulseaudio/core1/sink1"})
+
+	if client.profiles != 1 || len(client.filters) != 1 || client.filters[0] != "/org/pulseaudio/core1/sink1" {
+		t.Errorf("hooks: got %d profiles changes, filters %v", client.profiles, client.filters)
+	}
+}
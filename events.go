@@ -0,0 +1,203 @@
+package pulseaudio
+
+//go:generate go run ./cmd/pulsegen -events -pkg pulseaudio -out events_generated.go xml
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Event is a typed signal payload, as received with Subscribe.
+//
+type Event interface {
+	EventName() string // Signal name, as referenced in the Hooker.
+}
+
+// EventDef declares a signal: its name, how to decode its data, and how to
+// forward it to the objects registered with Register.
+//
+// Use DefineEvent to create it from typed functions.
+//
+type EventDef struct {
+	Name    string                            // Core signals without the interface prefix, extensions with their full name.
+	Type    reflect.Type                      // On... interface, for Hooker.Types.
+	Payload reflect.Type                      // Payload type, to find the event of Subscribe.
+	Decode  func(Msg) (Event, error)          // Converts the signal data to the typed payload.
+	Bind    func(obj interface{}) func(Event) // Returns the object On... method caller, or nil if not implemented.
+}
+
+// Call forwards a signal to the On... method of the message object, if it
+// implements it. Signals with invalid data are dropped.
+//
+func (def EventDef) Call(m Msg) {
+	notify := def.Bind(m.O)
+	if notify == nil {
+		return
+	}
+	ev, e := def.Decode(m)
+	if e == nil {
+		notify(ev)
+	}
+}
+
+// DefineEvent creates an event definition from the payload decoder and the
+// On... interface method caller. The event name is given by the payload type.
+//
+// The payload fields must be the On... method arguments, in order, for the
+// event to be used with Client.On.
+//
+func DefineEvent[T Event, I any](decode func(Msg) (T, error), notify func(I, T)) EventDef {
+	var zero T
+	return EventDef{
+		Name:    zero.EventName(),
+		Type:    reflect.TypeOf((*I)(nil)).Elem(),
+		Payload: reflect.TypeOf(zero),
+		Decode:  func(m Msg) (Event, error) { return decode(m) },
+		Bind: func(obj interface{}) func(Event) {
+			o, ok := obj.(I)
+			if !ok {
+				return nil
+			}
+			return func(ev Event) { notify(o, ev.(T)) }
+		},
+	}
+}
+
+// MsgArg returns the signal data at index i, with a checked type.
+//
+func MsgArg[T any](m Msg, i int) (val T, e error) {
+	if i >= len(m.D) {
+		return val, fmt.Errorf("signal data: missing value %d", i)
+	}
+	val, ok := m.D[i].(T)
+	if !ok {
+		return val, fmt.Errorf("signal data %d: got %T, want %T", i, m.D[i], val)
+	}
+	return val, nil
+}
+
+// StoreArgs stores the signal data in dest, converted with StoreValue. It's
+// used by the generated decoders.
+//
+func StoreArgs(m Msg, dest ...interface{}) error {
+	if len(m.D) < len(dest) {
+		return fmt.Errorf("signal data: got %d values, want %d", len(m.D), len(dest))
+	}
+	for i, d := range dest {
+		e := StoreValue(m.D[i], d)
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// Events is a table of event definitions.
+//
+type Events []EventDef
+
+// Calls returns the callback methods of the events, for Hooker.AddCalls.
+// Signals declared many times are forwarded to every definition the object
+// implements, like with AddEvents.
+//
+func (events Events) Calls() Calls {
+	calls := make(Calls, len(events))
+	for _, def := range events {
+		name := def.Name
+		calls[name] = func(m Msg) {
+			for _, def := range events {
+				if def.Name == name {
+					def.Call(m)
+				}
+			}
+		}
+	}
+	return calls
+}
+
+// Types returns the interfaces types of the events, for Hooker.AddTypes. The
+// first definition of a signal is used.
+//
+func (events Events) Types() Types {
+	types := make(Types, len(events))
+	for _, def := range events {
+		if _, ok := types[def.Name]; !ok {
+			types[def.Name] = def.Type
+		}
+	}
+	return types
+}
+
+// AddEvents registers a table of events, with their callback methods and
+// interfaces types.
+//
+// A signal can be declared many times, with different payloads and On...
+// interfaces (like NewSink, with the NewSink and NewSinkPath methods). Objects
+// receive the signal through every definition they implement. The interface
+// type of the first definition is the one used by Client.On.
+//
+func (hook Hooker) AddEvents(events Events) {
+	for _, def := range events {
+		name := def.Name
+		hook.Events[name] = append(hook.Events[name], def)
+		hook.Calls[name] = func(m Msg) {
+			for _, def := range hook.Events[name] {
+				def.Call(m)
+			}
+		}
+		if _, ok := hook.Types[name]; !ok {
+			hook.Types[name] = def.Type
+		}
+	}
+}
+
+// bind returns whether the object implements an event definition.
+//
+func (hook Hooker) bind(name string, obj interface{}) (found, implements bool) {
+	defs, found := hook.Events[name]
+	for _, def := range defs {
+		if def.Bind(obj) != nil {
+			return true, true
+		}
+	}
+	return found, false
+}
+
+// AddEvents registers a table of events, like generated bindings. See
+// Hooker.AddEvents.
+//
+func (pulse *Client) AddEvents(events Events) {
+	pulse.hooker.AddEvents(events)
+}
+
+// Subscribe registers a typed handler for the events of type T.
+//
+//   pulseaudio.Subscribe(pulse, func(ev pulseaudio.DeviceVolumeUpdated) {
+//   	fmt.Println("volume", ev.Path, ev.Volume)
+//   })
+//
+// The handle returned can be given to Client.Unregister to remove the handler.
+//
+func Subscribe[T Event](pulse *Client, handler func(T)) (*Handle, error) {
+	var zero T
+	name := zero.EventName()
+	var def EventDef
+	for _, test := range pulse.hooker.Events[name] {
+		if test.Payload == reflect.TypeOf(zero) {
+			def = test
+		}
+	}
+	if def.Decode == nil {
+		return nil, fmt.Errorf("subscribe %s: unknown event %T", name, zero)
+	}
+	h, tolisten := pulse.hooker.addHandle(name, func(m Msg) {
+		ev, e := def.Decode(m)
+		if e != nil {
+			return
+		}
+		if typed, ok := ev.(T); ok {
+			handler(typed)
+		}
+	})
+	return h, pulse.listenHandle(tolisten)
+}
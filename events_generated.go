@@ -0,0 +1,1246 @@
+// Code generated by pulsegen. DO NOT EDIT.
+
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+)
+
+//
+//--------------------------------------------------------------------[ CARD ]--
+
+// CardActiveProfileUpdated is the payload of the org.PulseAudio.Core1.Card.ActiveProfileUpdated signal.
+type CardActiveProfileUpdated struct {
+	Path    dbus.ObjectPath // Object emitting the signal.
+	Profile dbus.ObjectPath
+}
+
+// EventName returns the signal name of the event.
+func (CardActiveProfileUpdated) EventName() string { return "Card.ActiveProfileUpdated" }
+
+// OnCardActiveProfileUpdated is an interface to the CardActiveProfileUpdated method.
+type OnCardActiveProfileUpdated interface {
+	CardActiveProfileUpdated(dbus.ObjectPath, dbus.ObjectPath)
+}
+
+func decodeCardActiveProfileUpdated(m Msg) (ev CardActiveProfileUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Profile)
+	return ev, e
+}
+
+// CardNewProfile is the payload of the org.PulseAudio.Core1.Card.NewProfile signal.
+type CardNewProfile struct {
+	Path    dbus.ObjectPath // Object emitting the signal.
+	Profile dbus.ObjectPath
+}
+
+// EventName returns the signal name of the event.
+func (CardNewProfile) EventName() string { return "Card.NewProfile" }
+
+// OnCardNewProfile is an interface to the CardNewProfile method.
+type OnCardNewProfile interface {
+	CardNewProfile(dbus.ObjectPath, dbus.ObjectPath)
+}
+
+func decodeCardNewProfile(m Msg) (ev CardNewProfile, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Profile)
+	return ev, e
+}
+
+// CardProfileRemoved is the payload of the org.PulseAudio.Core1.Card.ProfileRemoved signal.
+type CardProfileRemoved struct {
+	Path    dbus.ObjectPath // Object emitting the signal.
+	Profile dbus.ObjectPath
+}
+
+// EventName returns the signal name of the event.
+func (CardProfileRemoved) EventName() string { return "Card.ProfileRemoved" }
+
+// OnCardProfileRemoved is an interface to the CardProfileRemoved method.
+type OnCardProfileRemoved interface {
+	CardProfileRemoved(dbus.ObjectPath, dbus.ObjectPath)
+}
+
+func decodeCardProfileRemoved(m Msg) (ev CardProfileRemoved, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Profile)
+	return ev, e
+}
+
+// CardPropertyListUpdated is the payload of the org.PulseAudio.Core1.Card.PropertyListUpdated signal.
+type CardPropertyListUpdated struct {
+	Path         dbus.ObjectPath // Object emitting the signal.
+	PropertyList PropertyList
+}
+
+// EventName returns the signal name of the event.
+func (CardPropertyListUpdated) EventName() string { return "Card.PropertyListUpdated" }
+
+// OnCardPropertyListUpdated is an interface to the CardPropertyListUpdated method.
+type OnCardPropertyListUpdated interface {
+	CardPropertyListUpdated(dbus.ObjectPath, PropertyList)
+}
+
+func decodeCardPropertyListUpdated(m Msg) (ev CardPropertyListUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.PropertyList)
+	return ev, e
+}
+
+//
+//------------------------------------------------------------------[ CLIENT ]--
+
+// ClientPropertyListUpdated is the payload of the org.PulseAudio.Core1.Client.PropertyListUpdated signal.
+type ClientPropertyListUpdated struct {
+	Path         dbus.ObjectPath // Object emitting the signal.
+	PropertyList PropertyList
+}
+
+// EventName returns the signal name of the event.
+func (ClientPropertyListUpdated) EventName() string { return "Client.PropertyListUpdated" }
+
+// OnClientPropertyListUpdated is an interface to the ClientPropertyListUpdated method.
+type OnClientPropertyListUpdated interface {
+	ClientPropertyListUpdated(dbus.ObjectPath, PropertyList)
+}
+
+func decodeClientPropertyListUpdated(m Msg) (ev ClientPropertyListUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.PropertyList)
+	return ev, e
+}
+
+// ClientClientEvent is the payload of the org.PulseAudio.Core1.Client.ClientEvent signal.
+type ClientClientEvent struct {
+	Path         dbus.ObjectPath // Object emitting the signal.
+	Name         string
+	PropertyList PropertyList
+}
+
+// EventName returns the signal name of the event.
+func (ClientClientEvent) EventName() string { return "Client.ClientEvent" }
+
+// OnClientClientEvent is an interface to the ClientClientEvent method.
+type OnClientClientEvent interface {
+	ClientClientEvent(dbus.ObjectPath, string, PropertyList)
+}
+
+func decodeClientClientEvent(m Msg) (ev ClientClientEvent, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Name, &ev.PropertyList)
+	return ev, e
+}
+
+//
+//--------------------------------------------------------------------[ CORE ]--
+
+// NewCard is the payload of the org.PulseAudio.Core1.NewCard signal.
+type NewCard struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (NewCard) EventName() string { return "NewCard" }
+
+// OnNewCard is an interface to the NewCard method.
+// The argument is the card path sent as signal data, not the emitter path.
+type OnNewCard interface {
+	NewCard(dbus.ObjectPath)
+}
+
+func decodeNewCard(m Msg) (ev NewCard, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// CardRemoved is the payload of the org.PulseAudio.Core1.CardRemoved signal.
+type CardRemoved struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (CardRemoved) EventName() string { return "CardRemoved" }
+
+// OnCardRemoved is an interface to the CardRemoved method.
+// The argument is the card path sent as signal data, not the emitter path.
+type OnCardRemoved interface {
+	CardRemoved(dbus.ObjectPath)
+}
+
+func decodeCardRemoved(m Msg) (ev CardRemoved, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// NewSink is the payload of the org.PulseAudio.Core1.NewSink signal.
+type NewSink struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (NewSink) EventName() string { return "NewSink" }
+
+// OnNewSinkPath is an interface to the NewSinkPath method.
+// The argument is the sink path sent as signal data, not the emitter path.
+type OnNewSinkPath interface {
+	NewSinkPath(dbus.ObjectPath)
+}
+
+func decodeNewSink(m Msg) (ev NewSink, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// emitterNewSink is the payload of the org.PulseAudio.Core1.NewSink signal for OnNewSink.
+type emitterNewSink struct {
+	Path dbus.ObjectPath // Object emitting the signal.
+}
+
+// EventName returns the signal name of the event.
+func (emitterNewSink) EventName() string { return "NewSink" }
+
+// OnNewSink is an interface to the NewSink method.
+// The argument is the signal path, the core object path. See OnNewSinkPath.
+type OnNewSink interface {
+	NewSink(dbus.ObjectPath)
+}
+
+func decodeEmitterNewSink(m Msg) (ev emitterNewSink, e error) {
+	ev.Path = m.P
+	return ev, nil
+}
+
+// SinkRemoved is the payload of the org.PulseAudio.Core1.SinkRemoved signal.
+type SinkRemoved struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (SinkRemoved) EventName() string { return "SinkRemoved" }
+
+// OnSinkRemovedPath is an interface to the SinkRemovedPath method.
+// The argument is the sink path sent as signal data, not the emitter path.
+type OnSinkRemovedPath interface {
+	SinkRemovedPath(dbus.ObjectPath)
+}
+
+func decodeSinkRemoved(m Msg) (ev SinkRemoved, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// emitterSinkRemoved is the payload of the org.PulseAudio.Core1.SinkRemoved signal for OnSinkRemoved.
+type emitterSinkRemoved struct {
+	Path dbus.ObjectPath // Object emitting the signal.
+}
+
+// EventName returns the signal name of the event.
+func (emitterSinkRemoved) EventName() string { return "SinkRemoved" }
+
+// OnSinkRemoved is an interface to the SinkRemoved method.
+// The argument is the signal path, the core object path. See OnSinkRemovedPath.
+type OnSinkRemoved interface {
+	SinkRemoved(dbus.ObjectPath)
+}
+
+func decodeEmitterSinkRemoved(m Msg) (ev emitterSinkRemoved, e error) {
+	ev.Path = m.P
+	return ev, nil
+}
+
+// FallbackSinkUpdated is the payload of the org.PulseAudio.Core1.FallbackSinkUpdated signal.
+type FallbackSinkUpdated struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (FallbackSinkUpdated) EventName() string { return "FallbackSinkUpdated" }
+
+// OnFallbackSinkUpdatedPath is an interface to the FallbackSinkUpdatedPath method.
+// The argument is the sink path sent as signal data, not the emitter path.
+type OnFallbackSinkUpdatedPath interface {
+	FallbackSinkUpdatedPath(dbus.ObjectPath)
+}
+
+func decodeFallbackSinkUpdated(m Msg) (ev FallbackSinkUpdated, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// emitterFallbackSinkUpdated is the payload of the org.PulseAudio.Core1.FallbackSinkUpdated signal for OnFallbackSinkUpdated.
+type emitterFallbackSinkUpdated struct {
+	Path dbus.ObjectPath // Object emitting the signal.
+}
+
+// EventName returns the signal name of the event.
+func (emitterFallbackSinkUpdated) EventName() string { return "FallbackSinkUpdated" }
+
+// OnFallbackSinkUpdated is an interface to the FallbackSinkUpdated method.
+// The argument is the signal path, the core object path. See OnFallbackSinkUpdatedPath.
+type OnFallbackSinkUpdated interface {
+	FallbackSinkUpdated(dbus.ObjectPath)
+}
+
+func decodeEmitterFallbackSinkUpdated(m Msg) (ev emitterFallbackSinkUpdated, e error) {
+	ev.Path = m.P
+	return ev, nil
+}
+
+// FallbackSinkUnset is the payload of the org.PulseAudio.Core1.FallbackSinkUnset signal.
+type FallbackSinkUnset struct{}
+
+// EventName returns the signal name of the event.
+func (FallbackSinkUnset) EventName() string { return "FallbackSinkUnset" }
+
+// OnFallbackSinkUnset is an interface to the FallbackSinkUnset method.
+type OnFallbackSinkUnset interface {
+	FallbackSinkUnset()
+}
+
+func decodeFallbackSinkUnset(m Msg) (ev FallbackSinkUnset, e error) {
+	return ev, e
+}
+
+// NewSource is the payload of the org.PulseAudio.Core1.NewSource signal.
+type NewSource struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (NewSource) EventName() string { return "NewSource" }
+
+// OnNewSource is an interface to the NewSource method.
+// The argument is the source path sent as signal data, not the emitter path.
+type OnNewSource interface {
+	NewSource(dbus.ObjectPath)
+}
+
+func decodeNewSource(m Msg) (ev NewSource, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// SourceRemoved is the payload of the org.PulseAudio.Core1.SourceRemoved signal.
+type SourceRemoved struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (SourceRemoved) EventName() string { return "SourceRemoved" }
+
+// OnSourceRemoved is an interface to the SourceRemoved method.
+// The argument is the source path sent as signal data, not the emitter path.
+type OnSourceRemoved interface {
+	SourceRemoved(dbus.ObjectPath)
+}
+
+func decodeSourceRemoved(m Msg) (ev SourceRemoved, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// FallbackSourceUpdated is the payload of the org.PulseAudio.Core1.FallbackSourceUpdated signal.
+type FallbackSourceUpdated struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (FallbackSourceUpdated) EventName() string { return "FallbackSourceUpdated" }
+
+// OnFallbackSourceUpdated is an interface to the FallbackSourceUpdated method.
+// The argument is the source path sent as signal data, not the emitter path.
+type OnFallbackSourceUpdated interface {
+	FallbackSourceUpdated(dbus.ObjectPath)
+}
+
+func decodeFallbackSourceUpdated(m Msg) (ev FallbackSourceUpdated, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// FallbackSourceUnset is the payload of the org.PulseAudio.Core1.FallbackSourceUnset signal.
+type FallbackSourceUnset struct{}
+
+// EventName returns the signal name of the event.
+func (FallbackSourceUnset) EventName() string { return "FallbackSourceUnset" }
+
+// OnFallbackSourceUnset is an interface to the FallbackSourceUnset method.
+type OnFallbackSourceUnset interface {
+	FallbackSourceUnset()
+}
+
+func decodeFallbackSourceUnset(m Msg) (ev FallbackSourceUnset, e error) {
+	return ev, e
+}
+
+// NewPlaybackStream is the payload of the org.PulseAudio.Core1.NewPlaybackStream signal.
+type NewPlaybackStream struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (NewPlaybackStream) EventName() string { return "NewPlaybackStream" }
+
+// OnNewPlaybackStream is an interface to the NewPlaybackStream method.
+// The argument is the playback stream path sent as signal data, not the emitter path.
+type OnNewPlaybackStream interface {
+	NewPlaybackStream(dbus.ObjectPath)
+}
+
+func decodeNewPlaybackStream(m Msg) (ev NewPlaybackStream, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// PlaybackStreamRemoved is the payload of the org.PulseAudio.Core1.PlaybackStreamRemoved signal.
+type PlaybackStreamRemoved struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (PlaybackStreamRemoved) EventName() string { return "PlaybackStreamRemoved" }
+
+// OnPlaybackStreamRemoved is an interface to the PlaybackStreamRemoved method.
+// The argument is the playback stream path sent as signal data, not the emitter path.
+type OnPlaybackStreamRemoved interface {
+	PlaybackStreamRemoved(dbus.ObjectPath)
+}
+
+func decodePlaybackStreamRemoved(m Msg) (ev PlaybackStreamRemoved, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// NewRecordStream is the payload of the org.PulseAudio.Core1.NewRecordStream signal.
+type NewRecordStream struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (NewRecordStream) EventName() string { return "NewRecordStream" }
+
+// OnNewRecordStream is an interface to the NewRecordStream method.
+// The argument is the record stream path sent as signal data, not the emitter path.
+type OnNewRecordStream interface {
+	NewRecordStream(dbus.ObjectPath)
+}
+
+func decodeNewRecordStream(m Msg) (ev NewRecordStream, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// RecordStreamRemoved is the payload of the org.PulseAudio.Core1.RecordStreamRemoved signal.
+type RecordStreamRemoved struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (RecordStreamRemoved) EventName() string { return "RecordStreamRemoved" }
+
+// OnRecordStreamRemoved is an interface to the RecordStreamRemoved method.
+// The argument is the record stream path sent as signal data, not the emitter path.
+type OnRecordStreamRemoved interface {
+	RecordStreamRemoved(dbus.ObjectPath)
+}
+
+func decodeRecordStreamRemoved(m Msg) (ev RecordStreamRemoved, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// NewSample is the payload of the org.PulseAudio.Core1.NewSample signal.
+type NewSample struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (NewSample) EventName() string { return "NewSample" }
+
+// OnNewSample is an interface to the NewSample method.
+// The argument is the sample path sent as signal data, not the emitter path.
+type OnNewSample interface {
+	NewSample(dbus.ObjectPath)
+}
+
+func decodeNewSample(m Msg) (ev NewSample, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// SampleRemoved is the payload of the org.PulseAudio.Core1.SampleRemoved signal.
+type SampleRemoved struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (SampleRemoved) EventName() string { return "SampleRemoved" }
+
+// OnSampleRemoved is an interface to the SampleRemoved method.
+// The argument is the sample path sent as signal data, not the emitter path.
+type OnSampleRemoved interface {
+	SampleRemoved(dbus.ObjectPath)
+}
+
+func decodeSampleRemoved(m Msg) (ev SampleRemoved, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// NewModule is the payload of the org.PulseAudio.Core1.NewModule signal.
+type NewModule struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (NewModule) EventName() string { return "NewModule" }
+
+// OnNewModule is an interface to the NewModule method.
+// The argument is the module path sent as signal data, not the emitter path.
+type OnNewModule interface {
+	NewModule(dbus.ObjectPath)
+}
+
+func decodeNewModule(m Msg) (ev NewModule, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// ModuleRemoved is the payload of the org.PulseAudio.Core1.ModuleRemoved signal.
+type ModuleRemoved struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (ModuleRemoved) EventName() string { return "ModuleRemoved" }
+
+// OnModuleRemoved is an interface to the ModuleRemoved method.
+// The argument is the module path sent as signal data, not the emitter path.
+type OnModuleRemoved interface {
+	ModuleRemoved(dbus.ObjectPath)
+}
+
+func decodeModuleRemoved(m Msg) (ev ModuleRemoved, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// NewClient is the payload of the org.PulseAudio.Core1.NewClient signal.
+type NewClient struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (NewClient) EventName() string { return "NewClient" }
+
+// OnNewClient is an interface to the NewClient method.
+// The argument is the client path sent as signal data, not the emitter path.
+type OnNewClient interface {
+	NewClient(dbus.ObjectPath)
+}
+
+func decodeNewClient(m Msg) (ev NewClient, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// ClientRemoved is the payload of the org.PulseAudio.Core1.ClientRemoved signal.
+type ClientRemoved struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (ClientRemoved) EventName() string { return "ClientRemoved" }
+
+// OnClientRemoved is an interface to the ClientRemoved method.
+// The argument is the client path sent as signal data, not the emitter path.
+type OnClientRemoved interface {
+	ClientRemoved(dbus.ObjectPath)
+}
+
+func decodeClientRemoved(m Msg) (ev ClientRemoved, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// NewExtension is the payload of the org.PulseAudio.Core1.NewExtension signal.
+type NewExtension struct {
+	Extension string
+}
+
+// EventName returns the signal name of the event.
+func (NewExtension) EventName() string { return "NewExtension" }
+
+// OnNewExtension is an interface to the NewExtension method.
+type OnNewExtension interface {
+	NewExtension(string)
+}
+
+func decodeNewExtension(m Msg) (ev NewExtension, e error) {
+	e = StoreArgs(m, &ev.Extension)
+	return ev, e
+}
+
+// ExtensionRemoved is the payload of the org.PulseAudio.Core1.ExtensionRemoved signal.
+type ExtensionRemoved struct {
+	Extension string
+}
+
+// EventName returns the signal name of the event.
+func (ExtensionRemoved) EventName() string { return "ExtensionRemoved" }
+
+// OnExtensionRemoved is an interface to the ExtensionRemoved method.
+type OnExtensionRemoved interface {
+	ExtensionRemoved(string)
+}
+
+func decodeExtensionRemoved(m Msg) (ev ExtensionRemoved, e error) {
+	e = StoreArgs(m, &ev.Extension)
+	return ev, e
+}
+
+//
+//--------------------------------------------------------------[ DEVICEPORT ]--
+
+// DevicePortAvailableChanged is the payload of the org.PulseAudio.Core1.DevicePort.AvailableChanged signal.
+type DevicePortAvailableChanged struct {
+	Path      dbus.ObjectPath // Object emitting the signal.
+	Available PortAvailability
+}
+
+// EventName returns the signal name of the event.
+func (DevicePortAvailableChanged) EventName() string { return "DevicePort.AvailableChanged" }
+
+// OnDevicePortAvailableChanged is an interface to the DevicePortAvailableChanged method.
+type OnDevicePortAvailableChanged interface {
+	DevicePortAvailableChanged(dbus.ObjectPath, PortAvailability)
+}
+
+func decodeDevicePortAvailableChanged(m Msg) (ev DevicePortAvailableChanged, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Available)
+	return ev, e
+}
+
+//
+//---------------------------------------------------------------[ EQUALIZER ]--
+
+// EqualizerFilterChanged is the payload of the org.PulseAudio.Ext.Equalizing1.Equalizer.FilterChanged signal.
+type EqualizerFilterChanged struct {
+	Path dbus.ObjectPath // Object emitting the signal.
+}
+
+// EventName returns the signal name of the event.
+func (EqualizerFilterChanged) EventName() string {
+	return "org.PulseAudio.Ext.Equalizing1.Equalizer.FilterChanged"
+}
+
+// OnEqualizerFilterChanged is an interface to the EqualizerFilterChanged method.
+type OnEqualizerFilterChanged interface {
+	EqualizerFilterChanged(dbus.ObjectPath)
+}
+
+func decodeEqualizerFilterChanged(m Msg) (ev EqualizerFilterChanged, e error) {
+	ev.Path = m.P
+	return ev, e
+}
+
+// EqualizerSinkReconfigured is the payload of the org.PulseAudio.Ext.Equalizing1.Equalizer.SinkReconfigured signal.
+type EqualizerSinkReconfigured struct {
+	Path dbus.ObjectPath // Object emitting the signal.
+}
+
+// EventName returns the signal name of the event.
+func (EqualizerSinkReconfigured) EventName() string {
+	return "org.PulseAudio.Ext.Equalizing1.Equalizer.SinkReconfigured"
+}
+
+// OnEqualizerSinkReconfigured is an interface to the EqualizerSinkReconfigured method.
+type OnEqualizerSinkReconfigured interface {
+	EqualizerSinkReconfigured(dbus.ObjectPath)
+}
+
+func decodeEqualizerSinkReconfigured(m Msg) (ev EqualizerSinkReconfigured, e error) {
+	ev.Path = m.P
+	return ev, e
+}
+
+//
+//--------------------------------------------------------[ EQUALIZERMANAGER ]--
+
+// EqualizerProfilesChanged is the payload of the org.PulseAudio.Ext.Equalizing1.Manager.ProfilesChanged signal.
+type EqualizerProfilesChanged struct{}
+
+// EventName returns the signal name of the event.
+func (EqualizerProfilesChanged) EventName() string {
+	return "org.PulseAudio.Ext.Equalizing1.Manager.ProfilesChanged"
+}
+
+// OnEqualizerProfilesChanged is an interface to the EqualizerProfilesChanged method.
+type OnEqualizerProfilesChanged interface {
+	EqualizerProfilesChanged()
+}
+
+func decodeEqualizerProfilesChanged(m Msg) (ev EqualizerProfilesChanged, e error) {
+	return ev, e
+}
+
+// EqualizerSinkAdded is the payload of the org.PulseAudio.Ext.Equalizing1.Manager.SinkAdded signal.
+type EqualizerSinkAdded struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (EqualizerSinkAdded) EventName() string {
+	return "org.PulseAudio.Ext.Equalizing1.Manager.SinkAdded"
+}
+
+// OnEqualizerSinkAdded is an interface to the EqualizerSinkAdded method.
+// The argument is the sink path sent as signal data, not the emitter path.
+type OnEqualizerSinkAdded interface {
+	EqualizerSinkAdded(dbus.ObjectPath)
+}
+
+func decodeEqualizerSinkAdded(m Msg) (ev EqualizerSinkAdded, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// EqualizerSinkRemoved is the payload of the org.PulseAudio.Ext.Equalizing1.Manager.SinkRemoved signal.
+type EqualizerSinkRemoved struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (EqualizerSinkRemoved) EventName() string {
+	return "org.PulseAudio.Ext.Equalizing1.Manager.SinkRemoved"
+}
+
+// OnEqualizerSinkRemoved is an interface to the EqualizerSinkRemoved method.
+// The argument is the sink path sent as signal data, not the emitter path.
+type OnEqualizerSinkRemoved interface {
+	EqualizerSinkRemoved(dbus.ObjectPath)
+}
+
+func decodeEqualizerSinkRemoved(m Msg) (ev EqualizerSinkRemoved, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+//
+//------------------------------------------------------------[ RESTOREENTRY ]--
+
+// RestoreEntryDeviceUpdated is the payload of the org.PulseAudio.Ext.StreamRestore1.RestoreEntry.DeviceUpdated signal.
+type RestoreEntryDeviceUpdated struct {
+	Path   dbus.ObjectPath // Object emitting the signal.
+	Device string
+}
+
+// EventName returns the signal name of the event.
+func (RestoreEntryDeviceUpdated) EventName() string {
+	return "org.PulseAudio.Ext.StreamRestore1.RestoreEntry.DeviceUpdated"
+}
+
+// OnRestoreEntryDeviceUpdated is an interface to the RestoreEntryDeviceUpdated method.
+type OnRestoreEntryDeviceUpdated interface {
+	RestoreEntryDeviceUpdated(dbus.ObjectPath, string)
+}
+
+func decodeRestoreEntryDeviceUpdated(m Msg) (ev RestoreEntryDeviceUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Device)
+	return ev, e
+}
+
+// RestoreEntryVolumeUpdated is the payload of the org.PulseAudio.Ext.StreamRestore1.RestoreEntry.VolumeUpdated signal.
+type RestoreEntryVolumeUpdated struct {
+	Path   dbus.ObjectPath // Object emitting the signal.
+	Volume []ChannelVolume
+}
+
+// EventName returns the signal name of the event.
+func (RestoreEntryVolumeUpdated) EventName() string {
+	return "org.PulseAudio.Ext.StreamRestore1.RestoreEntry.VolumeUpdated"
+}
+
+// OnRestoreEntryVolumeUpdated is an interface to the RestoreEntryVolumeUpdated method.
+type OnRestoreEntryVolumeUpdated interface {
+	RestoreEntryVolumeUpdated(dbus.ObjectPath, []ChannelVolume)
+}
+
+func decodeRestoreEntryVolumeUpdated(m Msg) (ev RestoreEntryVolumeUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Volume)
+	return ev, e
+}
+
+// RestoreEntryMuteUpdated is the payload of the org.PulseAudio.Ext.StreamRestore1.RestoreEntry.MuteUpdated signal.
+type RestoreEntryMuteUpdated struct {
+	Path  dbus.ObjectPath // Object emitting the signal.
+	Muted bool
+}
+
+// EventName returns the signal name of the event.
+func (RestoreEntryMuteUpdated) EventName() string {
+	return "org.PulseAudio.Ext.StreamRestore1.RestoreEntry.MuteUpdated"
+}
+
+// OnRestoreEntryMuteUpdated is an interface to the RestoreEntryMuteUpdated method.
+type OnRestoreEntryMuteUpdated interface {
+	RestoreEntryMuteUpdated(dbus.ObjectPath, bool)
+}
+
+func decodeRestoreEntryMuteUpdated(m Msg) (ev RestoreEntryMuteUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Muted)
+	return ev, e
+}
+
+//
+//------------------------------------------------------------------[ DEVICE ]--
+
+// DeviceVolumeUpdated is the payload of the org.PulseAudio.Core1.Device.VolumeUpdated signal.
+type DeviceVolumeUpdated struct {
+	Path   dbus.ObjectPath // Object emitting the signal.
+	Volume []uint32
+}
+
+// EventName returns the signal name of the event.
+func (DeviceVolumeUpdated) EventName() string { return "Device.VolumeUpdated" }
+
+// OnDeviceVolumeUpdated is an interface to the DeviceVolumeUpdated method.
+type OnDeviceVolumeUpdated interface {
+	DeviceVolumeUpdated(dbus.ObjectPath, []uint32)
+}
+
+func decodeDeviceVolumeUpdated(m Msg) (ev DeviceVolumeUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Volume)
+	return ev, e
+}
+
+// DeviceMuteUpdated is the payload of the org.PulseAudio.Core1.Device.MuteUpdated signal.
+type DeviceMuteUpdated struct {
+	Path  dbus.ObjectPath // Object emitting the signal.
+	Muted bool
+}
+
+// EventName returns the signal name of the event.
+func (DeviceMuteUpdated) EventName() string { return "Device.MuteUpdated" }
+
+// OnDeviceMuteUpdated is an interface to the DeviceMuteUpdated method.
+type OnDeviceMuteUpdated interface {
+	DeviceMuteUpdated(dbus.ObjectPath, bool)
+}
+
+func decodeDeviceMuteUpdated(m Msg) (ev DeviceMuteUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Muted)
+	return ev, e
+}
+
+// DeviceStateUpdated is the payload of the org.PulseAudio.Core1.Device.StateUpdated signal.
+type DeviceStateUpdated struct {
+	Path  dbus.ObjectPath // Object emitting the signal.
+	State DeviceState
+}
+
+// EventName returns the signal name of the event.
+func (DeviceStateUpdated) EventName() string { return "Device.StateUpdated" }
+
+// OnDeviceStateUpdated is an interface to the DeviceStateUpdated method.
+type OnDeviceStateUpdated interface {
+	DeviceStateUpdated(dbus.ObjectPath, DeviceState)
+}
+
+func decodeDeviceStateUpdated(m Msg) (ev DeviceStateUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.State)
+	return ev, e
+}
+
+// DeviceActivePortUpdated is the payload of the org.PulseAudio.Core1.Device.ActivePortUpdated signal.
+type DeviceActivePortUpdated struct {
+	Path dbus.ObjectPath // Object emitting the signal.
+	Port dbus.ObjectPath
+}
+
+// EventName returns the signal name of the event.
+func (DeviceActivePortUpdated) EventName() string { return "Device.ActivePortUpdated" }
+
+// OnDeviceActivePortUpdated is an interface to the DeviceActivePortUpdated method.
+type OnDeviceActivePortUpdated interface {
+	DeviceActivePortUpdated(dbus.ObjectPath, dbus.ObjectPath)
+}
+
+func decodeDeviceActivePortUpdated(m Msg) (ev DeviceActivePortUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Port)
+	return ev, e
+}
+
+// DevicePropertyListUpdated is the payload of the org.PulseAudio.Core1.Device.PropertyListUpdated signal.
+type DevicePropertyListUpdated struct {
+	Path         dbus.ObjectPath // Object emitting the signal.
+	PropertyList PropertyList
+}
+
+// EventName returns the signal name of the event.
+func (DevicePropertyListUpdated) EventName() string { return "Device.PropertyListUpdated" }
+
+// OnDevicePropertyListUpdated is an interface to the DevicePropertyListUpdated method.
+type OnDevicePropertyListUpdated interface {
+	DevicePropertyListUpdated(dbus.ObjectPath, PropertyList)
+}
+
+func decodeDevicePropertyListUpdated(m Msg) (ev DevicePropertyListUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.PropertyList)
+	return ev, e
+}
+
+//
+//------------------------------------------------------------------[ STREAM ]--
+
+// StreamDeviceUpdated is the payload of the org.PulseAudio.Core1.Stream.DeviceUpdated signal.
+type StreamDeviceUpdated struct {
+	Path   dbus.ObjectPath // Object emitting the signal.
+	Device dbus.ObjectPath
+}
+
+// EventName returns the signal name of the event.
+func (StreamDeviceUpdated) EventName() string { return "Stream.DeviceUpdated" }
+
+// OnStreamDeviceUpdated is an interface to the StreamDeviceUpdated method.
+type OnStreamDeviceUpdated interface {
+	StreamDeviceUpdated(dbus.ObjectPath, dbus.ObjectPath)
+}
+
+func decodeStreamDeviceUpdated(m Msg) (ev StreamDeviceUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Device)
+	return ev, e
+}
+
+// StreamSampleRateUpdated is the payload of the org.PulseAudio.Core1.Stream.SampleRateUpdated signal.
+type StreamSampleRateUpdated struct {
+	Path       dbus.ObjectPath // Object emitting the signal.
+	SampleRate uint32
+}
+
+// EventName returns the signal name of the event.
+func (StreamSampleRateUpdated) EventName() string { return "Stream.SampleRateUpdated" }
+
+// OnStreamSampleRateUpdated is an interface to the StreamSampleRateUpdated method.
+type OnStreamSampleRateUpdated interface {
+	StreamSampleRateUpdated(dbus.ObjectPath, uint32)
+}
+
+func decodeStreamSampleRateUpdated(m Msg) (ev StreamSampleRateUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.SampleRate)
+	return ev, e
+}
+
+// StreamVolumeUpdated is the payload of the org.PulseAudio.Core1.Stream.VolumeUpdated signal.
+type StreamVolumeUpdated struct {
+	Path   dbus.ObjectPath // Object emitting the signal.
+	Volume []uint32
+}
+
+// EventName returns the signal name of the event.
+func (StreamVolumeUpdated) EventName() string { return "Stream.VolumeUpdated" }
+
+// OnStreamVolumeUpdated is an interface to the StreamVolumeUpdated method.
+type OnStreamVolumeUpdated interface {
+	StreamVolumeUpdated(dbus.ObjectPath, []uint32)
+}
+
+func decodeStreamVolumeUpdated(m Msg) (ev StreamVolumeUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Volume)
+	return ev, e
+}
+
+// StreamMuteUpdated is the payload of the org.PulseAudio.Core1.Stream.MuteUpdated signal.
+type StreamMuteUpdated struct {
+	Path  dbus.ObjectPath // Object emitting the signal.
+	Muted bool
+}
+
+// EventName returns the signal name of the event.
+func (StreamMuteUpdated) EventName() string { return "Stream.MuteUpdated" }
+
+// OnStreamMuteUpdated is an interface to the StreamMuteUpdated method.
+type OnStreamMuteUpdated interface {
+	StreamMuteUpdated(dbus.ObjectPath, bool)
+}
+
+func decodeStreamMuteUpdated(m Msg) (ev StreamMuteUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Muted)
+	return ev, e
+}
+
+// StreamPropertyListUpdated is the payload of the org.PulseAudio.Core1.Stream.PropertyListUpdated signal.
+type StreamPropertyListUpdated struct {
+	Path         dbus.ObjectPath // Object emitting the signal.
+	PropertyList PropertyList
+}
+
+// EventName returns the signal name of the event.
+func (StreamPropertyListUpdated) EventName() string { return "Stream.PropertyListUpdated" }
+
+// OnStreamPropertyListUpdated is an interface to the StreamPropertyListUpdated method.
+type OnStreamPropertyListUpdated interface {
+	StreamPropertyListUpdated(dbus.ObjectPath, PropertyList)
+}
+
+func decodeStreamPropertyListUpdated(m Msg) (ev StreamPropertyListUpdated, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.PropertyList)
+	return ev, e
+}
+
+// StreamStreamEvent is the payload of the org.PulseAudio.Core1.Stream.StreamEvent signal.
+type StreamStreamEvent struct {
+	Path         dbus.ObjectPath // Object emitting the signal.
+	Name         string
+	PropertyList PropertyList
+}
+
+// EventName returns the signal name of the event.
+func (StreamStreamEvent) EventName() string { return "Stream.StreamEvent" }
+
+// OnStreamStreamEvent is an interface to the StreamStreamEvent method.
+type OnStreamStreamEvent interface {
+	StreamStreamEvent(dbus.ObjectPath, string, PropertyList)
+}
+
+func decodeStreamStreamEvent(m Msg) (ev StreamStreamEvent, e error) {
+	ev.Path = m.P
+	e = StoreArgs(m, &ev.Name, &ev.PropertyList)
+	return ev, e
+}
+
+//
+//-----------------------------------------------------------[ STREAMRESTORE ]--
+
+// StreamRestoreNewEntry is the payload of the org.PulseAudio.Ext.StreamRestore1.NewEntry signal.
+type StreamRestoreNewEntry struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (StreamRestoreNewEntry) EventName() string { return "org.PulseAudio.Ext.StreamRestore1.NewEntry" }
+
+// OnStreamRestoreNewEntry is an interface to the StreamRestoreNewEntry method.
+// The argument is the entry path sent as signal data, not the emitter path.
+type OnStreamRestoreNewEntry interface {
+	StreamRestoreNewEntry(dbus.ObjectPath)
+}
+
+func decodeStreamRestoreNewEntry(m Msg) (ev StreamRestoreNewEntry, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+// StreamRestoreEntryRemoved is the payload of the org.PulseAudio.Ext.StreamRestore1.EntryRemoved signal.
+type StreamRestoreEntryRemoved struct {
+	Path dbus.ObjectPath // Sent as signal data, not the emitter.
+}
+
+// EventName returns the signal name of the event.
+func (StreamRestoreEntryRemoved) EventName() string {
+	return "org.PulseAudio.Ext.StreamRestore1.EntryRemoved"
+}
+
+// OnStreamRestoreEntryRemoved is an interface to the StreamRestoreEntryRemoved method.
+// The argument is the entry path sent as signal data, not the emitter path.
+type OnStreamRestoreEntryRemoved interface {
+	StreamRestoreEntryRemoved(dbus.ObjectPath)
+}
+
+func decodeStreamRestoreEntryRemoved(m Msg) (ev StreamRestoreEntryRemoved, e error) {
+	e = StoreArgs(m, &ev.Path)
+	return ev, e
+}
+
+//
+//-----------------------------------------------------------[ EVENTS TABLES ]--
+
+// PulseEvents declares the core signals.
+// Public so it can be hacked before the client creation.
+var PulseEvents = Events{
+	DefineEvent(decodeCardActiveProfileUpdated, func(o OnCardActiveProfileUpdated, ev CardActiveProfileUpdated) {
+		o.CardActiveProfileUpdated(ev.Path, ev.Profile)
+	}),
+	DefineEvent(decodeCardNewProfile, func(o OnCardNewProfile, ev CardNewProfile) {
+		o.CardNewProfile(ev.Path, ev.Profile)
+	}),
+	DefineEvent(decodeCardProfileRemoved, func(o OnCardProfileRemoved, ev CardProfileRemoved) {
+		o.CardProfileRemoved(ev.Path, ev.Profile)
+	}),
+	DefineEvent(decodeCardPropertyListUpdated, func(o OnCardPropertyListUpdated, ev CardPropertyListUpdated) {
+		o.CardPropertyListUpdated(ev.Path, ev.PropertyList)
+	}),
+	DefineEvent(decodeClientPropertyListUpdated, func(o OnClientPropertyListUpdated, ev ClientPropertyListUpdated) {
+		o.ClientPropertyListUpdated(ev.Path, ev.PropertyList)
+	}),
+	DefineEvent(decodeClientClientEvent, func(o OnClientClientEvent, ev ClientClientEvent) {
+		o.ClientClientEvent(ev.Path, ev.Name, ev.PropertyList)
+	}),
+	DefineEvent(decodeNewCard, func(o OnNewCard, ev NewCard) {
+		o.NewCard(ev.Path)
+	}),
+	DefineEvent(decodeCardRemoved, func(o OnCardRemoved, ev CardRemoved) {
+		o.CardRemoved(ev.Path)
+	}),
+	DefineEvent(decodeEmitterNewSink, func(o OnNewSink, ev emitterNewSink) {
+		o.NewSink(ev.Path)
+	}),
+	DefineEvent(decodeNewSink, func(o OnNewSinkPath, ev NewSink) {
+		o.NewSinkPath(ev.Path)
+	}),
+	DefineEvent(decodeEmitterSinkRemoved, func(o OnSinkRemoved, ev emitterSinkRemoved) {
+		o.SinkRemoved(ev.Path)
+	}),
+	DefineEvent(decodeSinkRemoved, func(o OnSinkRemovedPath, ev SinkRemoved) {
+		o.SinkRemovedPath(ev.Path)
+	}),
+	DefineEvent(decodeEmitterFallbackSinkUpdated, func(o OnFallbackSinkUpdated, ev emitterFallbackSinkUpdated) {
+		o.FallbackSinkUpdated(ev.Path)
+	}),
+	DefineEvent(decodeFallbackSinkUpdated, func(o OnFallbackSinkUpdatedPath, ev FallbackSinkUpdated) {
+		o.FallbackSinkUpdatedPath(ev.Path)
+	}),
+	DefineEvent(decodeFallbackSinkUnset, func(o OnFallbackSinkUnset, ev FallbackSinkUnset) {
+		o.FallbackSinkUnset()
+	}),
+	DefineEvent(decodeNewSource, func(o OnNewSource, ev NewSource) {
+		o.NewSource(ev.Path)
+	}),
+	DefineEvent(decodeSourceRemoved, func(o OnSourceRemoved, ev SourceRemoved) {
+		o.SourceRemoved(ev.Path)
+	}),
+	DefineEvent(decodeFallbackSourceUpdated, func(o OnFallbackSourceUpdated, ev FallbackSourceUpdated) {
+		o.FallbackSourceUpdated(ev.Path)
+	}),
+	DefineEvent(decodeFallbackSourceUnset, func(o OnFallbackSourceUnset, ev FallbackSourceUnset) {
+		o.FallbackSourceUnset()
+	}),
+	DefineEvent(decodeNewPlaybackStream, func(o OnNewPlaybackStream, ev NewPlaybackStream) {
+		o.NewPlaybackStream(ev.Path)
+	}),
+	DefineEvent(decodePlaybackStreamRemoved, func(o OnPlaybackStreamRemoved, ev PlaybackStreamRemoved) {
+		o.PlaybackStreamRemoved(ev.Path)
+	}),
+	DefineEvent(decodeNewRecordStream, func(o OnNewRecordStream, ev NewRecordStream) {
+		o.NewRecordStream(ev.Path)
+	}),
+	DefineEvent(decodeRecordStreamRemoved, func(o OnRecordStreamRemoved, ev RecordStreamRemoved) {
+		o.RecordStreamRemoved(ev.Path)
+	}),
+	DefineEvent(decodeNewSample, func(o OnNewSample, ev NewSample) {
+		o.NewSample(ev.Path)
+	}),
+	DefineEvent(decodeSampleRemoved, func(o OnSampleRemoved, ev SampleRemoved) {
+		o.SampleRemoved(ev.Path)
+	}),
+	DefineEvent(decodeNewModule, func(o OnNewModule, ev NewModule) {
+		o.NewModule(ev.Path)
+	}),
+	DefineEvent(decodeModuleRemoved, func(o OnModuleRemoved, ev ModuleRemoved) {
+		o.ModuleRemoved(ev.Path)
+	}),
+	DefineEvent(decodeNewClient, func(o OnNewClient, ev NewClient) {
+		o.NewClient(ev.Path)
+	}),
+	DefineEvent(decodeClientRemoved, func(o OnClientRemoved, ev ClientRemoved) {
+		o.ClientRemoved(ev.Path)
+	}),
+	DefineEvent(decodeNewExtension, func(o OnNewExtension, ev NewExtension) {
+		o.NewExtension(ev.Extension)
+	}),
+	DefineEvent(decodeExtensionRemoved, func(o OnExtensionRemoved, ev ExtensionRemoved) {
+		o.ExtensionRemoved(ev.Extension)
+	}),
+	DefineEvent(decodeDevicePortAvailableChanged, func(o OnDevicePortAvailableChanged, ev DevicePortAvailableChanged) {
+		o.DevicePortAvailableChanged(ev.Path, ev.Available)
+	}),
+	DefineEvent(decodeDeviceVolumeUpdated, func(o OnDeviceVolumeUpdated, ev DeviceVolumeUpdated) {
+		o.DeviceVolumeUpdated(ev.Path, ev.Volume)
+	}),
+	DefineEvent(decodeDeviceMuteUpdated, func(o OnDeviceMuteUpdated, ev DeviceMuteUpdated) {
+		o.DeviceMuteUpdated(ev.Path, ev.Muted)
+	}),
+	DefineEvent(decodeDeviceStateUpdated, func(o OnDeviceStateUpdated, ev DeviceStateUpdated) {
+		o.DeviceStateUpdated(ev.Path, ev.State)
+	}),
+	DefineEvent(decodeDeviceActivePortUpdated, func(o OnDeviceActivePortUpdated, ev DeviceActivePortUpdated) {
+		o.DeviceActivePortUpdated(ev.Path, ev.Port)
+	}),
+	DefineEvent(decodeDevicePropertyListUpdated, func(o OnDevicePropertyListUpdated, ev DevicePropertyListUpdated) {
+		o.DevicePropertyListUpdated(ev.Path, ev.PropertyList)
+	}),
+	DefineEvent(decodeStreamDeviceUpdated, func(o OnStreamDeviceUpdated, ev StreamDeviceUpdated) {
+		o.StreamDeviceUpdated(ev.Path, ev.Device)
+	}),
+	DefineEvent(decodeStreamSampleRateUpdated, func(o OnStreamSampleRateUpdated, ev StreamSampleRateUpdated) {
+		o.StreamSampleRateUpdated(ev.Path, ev.SampleRate)
+	}),
+	DefineEvent(decodeStreamVolumeUpdated, func(o OnStreamVolumeUpdated, ev StreamVolumeUpdated) {
+		o.StreamVolumeUpdated(ev.Path, ev.Volume)
+	}),
+	DefineEvent(decodeStreamMuteUpdated, func(o OnStreamMuteUpdated, ev StreamMuteUpdated) {
+		o.StreamMuteUpdated(ev.Path, ev.Muted)
+	}),
+	DefineEvent(decodeStreamPropertyListUpdated, func(o OnStreamPropertyListUpdated, ev StreamPropertyListUpdated) {
+		o.StreamPropertyListUpdated(ev.Path, ev.PropertyList)
+	}),
+	DefineEvent(decodeStreamStreamEvent, func(o OnStreamStreamEvent, ev StreamStreamEvent) {
+		o.StreamStreamEvent(ev.Path, ev.Name, ev.PropertyList)
+	}),
+}
+
+// EqualizerEvents declares the equalizer signals.
+// Public so it can be hacked before the client creation.
+var EqualizerEvents = Events{
+	DefineEvent(decodeEqualizerFilterChanged, func(o OnEqualizerFilterChanged, ev EqualizerFilterChanged) {
+		o.EqualizerFilterChanged(ev.Path)
+	}),
+	DefineEvent(decodeEqualizerSinkReconfigured, func(o OnEqualizerSinkReconfigured, ev EqualizerSinkReconfigured) {
+		o.EqualizerSinkReconfigured(ev.Path)
+	}),
+	DefineEvent(decodeEqualizerProfilesChanged, func(o OnEqualizerProfilesChanged, ev EqualizerProfilesChanged) {
+		o.EqualizerProfilesChanged()
+	}),
+	DefineEvent(decodeEqualizerSinkAdded, func(o OnEqualizerSinkAdded, ev EqualizerSinkAdded) {
+		o.EqualizerSinkAdded(ev.Path)
+	}),
+	DefineEvent(decodeEqualizerSinkRemoved, func(o OnEqualizerSinkRemoved, ev EqualizerSinkRemoved) {
+		o.EqualizerSinkRemoved(ev.Path)
+	}),
+}
+
+// StreamRestoreEvents declares the stream restore signals.
+// Public so it can be hacked before the client creation.
+var StreamRestoreEvents = Events{
+	DefineEvent(decodeRestoreEntryDeviceUpdated, func(o OnRestoreEntryDeviceUpdated, ev RestoreEntryDeviceUpdated) {
+		o.RestoreEntryDeviceUpdated(ev.Path, ev.Device)
+	}),
+	DefineEvent(decodeRestoreEntryVolumeUpdated, func(o OnRestoreEntryVolumeUpdated, ev RestoreEntryVolumeUpdated) {
+		o.RestoreEntryVolumeUpdated(ev.Path, ev.Volume)
+	}),
+	DefineEvent(decodeRestoreEntryMuteUpdated, func(o OnRestoreEntryMuteUpdated, ev RestoreEntryMuteUpdated) {
+		o.RestoreEntryMuteUpdated(ev.Path, ev.Muted)
+	}),
+	DefineEvent(decodeStreamRestoreNewEntry, func(o OnStreamRestoreNewEntry, ev StreamRestoreNewEntry) {
+		o.StreamRestoreNewEntry(ev.Path)
+	}),
+	DefineEvent(decodeStreamRestoreEntryRemoved, func(o OnStreamRestoreEntryRemoved, ev StreamRestoreEntryRemoved) {
+		o.StreamRestoreEntryRemoved(ev.Path)
+	}),
+}
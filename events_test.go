@@ -0,0 +1,98 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"reflect"
+	"testing"
+)
+
+func TestSubscribe(t *testing.T) {
+	pulse := pulseaudio.NewReplayClient()
+	pulse.SetOnUnknownSignal(func(*dbus.Signal) {})
+
+	var volumes []pulseaudio.DeviceVolumeUpdated
+	var states []pulseaudio.DeviceStateUpdated
+	hVolume, e := pulseaudio.Subscribe(pulse, func(ev pulseaudio.DeviceVolumeUpdated) { volumes = append(volumes, ev) })
+	if e != nil {
+		t.Fatal("subscribe volume:", e)
+	}
+	_, e = pulseaudio.Subscribe[pulseaudio.DeviceStateUpdated](pulse, func(ev pulseaudio.DeviceStateUpdated) { states = append(states, ev) })
+	if e != nil {
+		t.Fatal("subscribe state:", e)
+	}
+
+	rec := &volumeRecorder{volumes: make(map[dbus.ObjectPath][][]uint32)}
+	pulse.Register(rec)
+
+	dispatch := func(name string, path dbus.ObjectPath, body ...interface{}) {
+		pulse.DispatchSignal(&dbus.Signal{Name: pulseaudio.DbusInterface + "." + name, Path: path, Body: body})
+	}
+	dispatch("Device.VolumeUpdated", "/sink0", []uint32{42})
+	dispatch("Device.VolumeUpdated", "/sink0", "invalid")
+	dispatch("Device.StateUpdated", "/sink0", uint32(pulseaudio.StateRunning))
+	dispatch("SinkRemoved", pulseaudio.DbusPath, dbus.ObjectPath("/sink1"))
+
+	pulse.Unregister(hVolume)
+	dispatch("Device.VolumeUpdated", "/sink0", []uint32{43})
+
+	if want := []pulseaudio.DeviceVolumeUpdated{{Path: "/sink0", Volume: []uint32{42}}}; !reflect.DeepEqual(volumes, want) {
+		t.Errorf("volumes: got %v, want %v", volumes, want)
+	}
+	if want := []pulseaudio.DeviceStateUpdated{{Path: "/sink0", State: pulseaudio.StateRunning}}; !reflect.DeepEqual(states, want) {
+		t.Errorf("states: got %v, want %v", states, want)
+	}
+	wantRec := map[dbus.ObjectPath][][]uint32{"/sink0": {{42}, {43}}}
+	if !reflect.DeepEqual(rec.volumes, wantRec) || !reflect.DeepEqual(rec.removed, []dbus.ObjectPath{"/sink1"}) {
+		t.Errorf("register: got volumes %v, removed %v", rec.volumes, rec.removed)
+	}
+}
+
+// testEvent is an extension event defined with DefineEvent.
+type testEvent struct {
+	Path  dbus.ObjectPath
+	Value string
+}
+
+func (testEvent) EventName() string { return "org.PulseAudio.Ext.Test.Updated" }
+
+type onTestEvent interface {
+	TestUpdated(dbus.ObjectPath, string)
+}
+
+type testClient struct{ values []string }
+
+func (tc *testClient) TestUpdated(path dbus.ObjectPath, value string) {
+	tc.values = append(tc.values, string(path)+"="+value)
+}
+
+func TestDefineEvent(t *testing.T) {
+	events := pulseaudio.Events{pulseaudio.DefineEvent(
+		func(m pulseaudio.Msg) (ev testEvent, e error) {
+			ev.Path = m.P
+			ev.Value, e = pulseaudio.MsgArg[string](m, 0)
+			return ev, e
+		},
+		func(o onTestEvent, ev testEvent) { o.TestUpdated(ev.Path, ev.Value) },
+	)}
+
+	hook := pulseaudio.NewHooker()
+	hook.AddEvents(events)
+
+	tc := &testClient{}
+	if tolisten := hook.Register(tc); !reflect.DeepEqual(tolisten, []string{"org.PulseAudio.Ext.Test.Updated"}) {
+		t.Errorf("register: got tolisten %v", tolisten)
+	}
+	hook.Register(&volumeRecorder{}) // Doesn't implement the event.
+	hook.Call("org.PulseAudio.Ext.Test.Updated", &dbus.Signal{Path: "/test", Body: []interface{}{"value"}})
+	hook.Call("org.PulseAudio.Ext.Test.Updated", &dbus.Signal{Path: "/test", Body: []interface{}{uint32(1)}})
+
+	if want := []string{"/test=value"}; !reflect.DeepEqual(tc.values, want) {
+		t.Errorf("calls: got %v, want %v", tc.values, want)
+	}
+	if types := events.Types(); types["org.PulseAudio.Ext.Test.Updated"] != reflect.TypeOf((*onTestEvent)(nil)).Elem() {
+		t.Errorf("types: got %v", types)
+	}
+}
@@ -0,0 +1,394 @@
+// Package exporter exposes the pulseaudio server state as Prometheus metrics.
+//
+// The Exporter keeps a view of devices and streams updated by pulseaudio
+// signals: volume, mute, state and active port changes, and streams moves are
+// received as events, and objects are only queried when they appear. Values
+// without signals, like latencies and memory statistics, are queried at each
+// scrape.
+//
+// Metrics are written in the Prometheus text format, without dependency on the
+// Prometheus client library:
+//   pulseaudio_up                          Whether the last scrape could query the server.
+//   pulseaudio_device_volume               Device volume ratio, by channel (1 is 100%).
+//   pulseaudio_device_muted                Device mute state.
+//   pulseaudio_device_state                Device state, 1 for the current one.
+//   pulseaudio_device_active_port          Device active port, as a port label.
+//   pulseaudio_device_latency_seconds      Length of queued audio in the device.
+//   pulseaudio_stream_volume               Stream volume ratio, by channel.
+//   pulseaudio_stream_muted                Stream mute state.
+//   pulseaudio_stream_latency_seconds      Buffered audio, in the stream and the device.
+//   pulseaudio_streams                     Number of streams by application.
+//   pulseaudio_memstats_*                  Server memory statistics.
+//
+// Devices are labeled with type (sink or source) and name, streams with type
+// (playback or record), index and device. More labels are read from the
+// PropertyList, see DeviceLabels and StreamLabels.
+//
+// Usage:
+//   exp, e := exporter.New(pulse)
+//   ...
+//   go pulse.Listen()
+//   http.Handle("/metrics", exp)
+//
+package exporter
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultDeviceLabels defines the device labels read from the PropertyList.
+var DefaultDeviceLabels = map[string]string{
+	"description": pulseaudio.PropDeviceDescription,
+	"form_factor": pulseaudio.PropDeviceFormFactor,
+	"bus":         pulseaudio.PropDeviceBus,
+}
+
+// DefaultStreamLabels defines the stream labels read from the PropertyList.
+var DefaultStreamLabels = map[string]string{
+	"application": pulseaudio.PropApplicationName,
+	"role":        pulseaudio.PropMediaRole,
+}
+
+// Object kinds, by Core list property, with their type label.
+var kinds = []struct {
+	list, label string
+	device      bool
+}{
+	{"Sinks", "sink", true},
+	{"Sources", "source", true},
+	{"PlaybackStreams", "playback", false},
+	{"RecordStreams", "record", false},
+}
+
+// Exporter collects the pulseaudio metrics.
+//
+type Exporter struct {
+	// DeviceLabels and StreamLabels define the labels read from the object
+	// PropertyList, as label name to property key.
+	// Defaults to DefaultDeviceLabels and DefaultStreamLabels.
+	DeviceLabels map[string]string
+	StreamLabels map[string]string
+
+	pulse   *pulseaudio.Client
+	mu      sync.Mutex
+	objects map[dbus.ObjectPath]*object
+}
+
+// object is the known state of a device or stream.
+//
+type object struct {
+	kind   string // Type label.
+	device bool
+	labels labels // Labels identifying the object.
+	volume []uint32
+	mute   bool
+	state  pulseaudio.DeviceState
+	port   string
+	dev    dbus.ObjectPath // Device of a stream.
+}
+
+// New creates an exporter for the pulseaudio client, and registers it to
+// receive the events.
+//
+func New(pulse *pulseaudio.Client) (*Exporter, error) {
+	exp := &Exporter{
+		DeviceLabels: DefaultDeviceLabels,
+		StreamLabels: DefaultStreamLabels,
+		pulse:        pulse,
+		objects:      make(map[dbus.ObjectPath]*object),
+	}
+	if errs := pulse.Register(exp); len(errs) > 0 {
+		pulse.Unregister(exp)
+		return nil, errs[0]
+	}
+	return exp, nil
+}
+
+// Close unregisters the exporter from the pulseaudio events.
+//
+func (exp *Exporter) Close() error {
+	if errs := exp.pulse.Unregister(exp); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+//
+func (exp *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	exp.WriteTo(&buf)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	buf.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format.
+//
+func (exp *Exporter) WriteTo(w io.Writer) (int64, error) {
+	set := newMetricSet()
+	up := 1.
+	if e := exp.sync(); e != nil {
+		up = 0
+	}
+	set.add("pulseaudio_up", gauge, "Whether the last scrape could query the pulseaudio server.", nil, up)
+
+	exp.collectObjects(set, exp.snapshot())
+	exp.collectMemstats(set)
+	return set.writeTo(w)
+}
+
+// sync loads objects not known yet and forgets removed ones. Objects announced
+// by signals are already known, this handles the others (sources and record
+// streams) and the initial state.
+//
+func (exp *Exporter) sync() error {
+	lists := make([][]dbus.ObjectPath, len(kinds))
+	for i, k := range kinds {
+		paths, e := exp.pulse.Core().ListPath(k.list)
+		if e != nil {
+			return e
+		}
+		lists[i] = paths
+	}
+
+	current := make(map[dbus.ObjectPath]bool)
+	missing := make(map[dbus.ObjectPath]int) // Kind index.
+	exp.mu.Lock()
+	for i, paths := range lists {
+		for _, path := range paths {
+			current[path] = true
+			if _, ok := exp.objects[path]; !ok {
+				missing[path] = i
+			}
+		}
+	}
+	for path := range exp.objects {
+		if !current[path] {
+			delete(exp.objects, path)
+		}
+	}
+	exp.mu.Unlock()
+
+	for path, i := range missing {
+		exp.add(path, kinds[i].label, kinds[i].device)
+	}
+	return nil
+}
+
+// add loads an object and adds it to the known ones, unless it was added in
+// the meantime.
+//
+func (exp *Exporter) add(path dbus.ObjectPath, kind string, device bool) {
+	obj := exp.load(path, kind, device)
+	if obj == nil {
+		return
+	}
+	exp.mu.Lock()
+	defer exp.mu.Unlock()
+	if _, ok := exp.objects[path]; !ok {
+		exp.objects[path] = obj
+	}
+}
+
+// load queries the state of an object. Objects that can't be queried are
+// ignored (nil), they may have been removed already.
+//
+func (exp *Exporter) load(path dbus.ObjectPath, kind string, device bool) *object {
+	obj := &object{kind: kind, device: device}
+	var props pulseaudio.PropertyList
+	if device {
+		dev := exp.pulse.TypedDevice(path)
+		name, e := dev.Name()
+		if e != nil {
+			return nil
+		}
+		obj.labels = labels{{"type", kind}, {"name", name}}
+		props, _ = dev.PropertyList()
+		obj.labels = obj.labels.fromProperties(exp.DeviceLabels, props)
+		obj.volume, _ = dev.ListUint32("Volume")
+		obj.mute, _ = dev.Bool("Mute")
+		obj.state, _ = dev.State()
+		if port, e := dev.ActivePort(); e == nil {
+			obj.port, _ = port.Name()
+		}
+
+	} else {
+		stream := exp.pulse.TypedStream(path)
+		index, e := stream.Uint32("Index")
+		if e != nil {
+			return nil
+		}
+		obj.labels = labels{{"type", kind}, {"index", formatUint(uint64(index))}, {"device", ""}}
+		props, _ = stream.PropertyList()
+		obj.labels = obj.labels.fromProperties(exp.StreamLabels, props)
+		obj.volume, _ = stream.ListUint32("Volume")
+		obj.mute, _ = stream.Bool("Mute")
+		obj.dev, _ = stream.Device()
+	}
+	return obj
+}
+
+// snapshot returns a copy of the known objects, to collect them without the
+// lock. The streams device label is set from the known devices names.
+//
+func (exp *Exporter) snapshot() map[dbus.ObjectPath]object {
+	exp.mu.Lock()
+	defer exp.mu.Unlock()
+	objects := make(map[dbus.ObjectPath]object, len(exp.objects))
+	for path, obj := range exp.objects {
+		cp := *obj
+		if !cp.device {
+			name := ""
+			if dev, ok := exp.objects[cp.dev]; ok {
+				name = dev.labels.get("name")
+			}
+			cp.labels = cp.labels.with("device", name)
+		}
+		objects[path] = cp
+	}
+	return objects
+}
+
+// collectObjects adds the devices and streams metrics.
+//
+func (exp *Exporter) collectObjects(set *metricSet, objects map[dbus.ObjectPath]object) {
+	streams := make(map[string]int) // Count by type and application.
+	appLabel := ""
+	for name, key := range exp.StreamLabels {
+		if key == pulseaudio.PropApplicationName {
+			appLabel = name
+		}
+	}
+
+	for path, obj := range objects {
+		prefix := "pulseaudio_device_"
+		if !obj.device {
+			prefix = "pulseaudio_stream_"
+		}
+
+		for i, vol := range obj.volume {
+			set.add(prefix+"volume", gauge, "Volume ratio by channel, 1 is 100%.",
+				obj.labels.with("channel", formatUint(uint64(i))),
+				float64(vol)/float64(pulseaudio.VolumeNorm))
+		}
+		set.add(prefix+"muted", gauge, "Whether the object is muted.", obj.labels, boolValue(obj.mute))
+
+		if obj.device {
+			for _, state := range []pulseaudio.DeviceState{pulseaudio.StateRunning, pulseaudio.StateIdle, pulseaudio.StateSuspended} {
+				set.add(prefix+"state", gauge, "Device state, 1 for the current state.",
+					obj.labels.with("state", state.String()), boolValue(obj.state == state))
+			}
+			if obj.port != "" {
+				set.add(prefix+"active_port", gauge, "Device active port.", obj.labels.with("port", obj.port), 1)
+			}
+			if latency, e := exp.pulse.Device(path).Uint64("Latency"); e == nil {
+				set.add(prefix+"latency_seconds", gauge, "Length of queued audio in the device.", obj.labels, float64(latency)/1e6)
+			}
+			continue
+		}
+
+		stream := exp.pulse.TypedStream(path)
+		for _, lat := range []string{"Buffer", "Device"} {
+			if latency, e := stream.Uint64(lat + "Latency"); e == nil {
+				set.add(prefix+"latency_seconds", gauge, "Length of buffered audio, in the stream (buffer) and at the device.",
+					obj.labels.with("buffer", lowerFirst(lat)), float64(latency)/1e6)
+			}
+		}
+		streams[obj.kind+"\x00"+obj.labels.get(appLabel)]++
+	}
+
+	for key, count := range streams {
+		kind, app := splitKey(key)
+		lbl := labels{{"type", kind}}
+		if appLabel != "" {
+			lbl = lbl.with(appLabel, app)
+		}
+		set.add("pulseaudio_streams", gauge, "Number of streams by application.", lbl, float64(count))
+	}
+}
+
+// collectMemstats adds the server memory statistics.
+//
+func (exp *Exporter) collectMemstats(set *metricSet) {
+	snap, e := exp.pulse.Memstats().Snapshot()
+	if e != nil {
+		return
+	}
+	set.add("pulseaudio_memstats_current_memblocks", gauge, "Number of memory blocks currently allocated.", nil, float64(snap.CurrentMemblocks))
+	set.add("pulseaudio_memstats_current_memblocks_bytes", gauge, "Size of memory blocks currently allocated.", nil, float64(snap.CurrentMemblocksSize))
+	set.add("pulseaudio_memstats_accumulated_memblocks_total", counter, "Number of memory blocks allocated since the server start.", nil, float64(snap.AccumulatedMemblocks))
+	set.add("pulseaudio_memstats_accumulated_memblocks_bytes_total", counter, "Size of memory blocks allocated since the server start.", nil, float64(snap.AccumulatedMemblocksSize))
+	set.add("pulseaudio_memstats_sample_cache_bytes", gauge, "Size of the sample cache.", nil, float64(snap.SampleCacheSize))
+}
+
+//
+//--------------------------------------------------------[ PULSE CALLBACKS ]--
+
+// NewSinkPath loads the new sink.
+func (exp *Exporter) NewSinkPath(path dbus.ObjectPath) { exp.add(path, "sink", true) }
+
+// SinkRemovedPath forgets the sink.
+func (exp *Exporter) SinkRemovedPath(path dbus.ObjectPath) { exp.remove(path) }
+
+// NewPlaybackStream loads the new stream.
+func (exp *Exporter) NewPlaybackStream(path dbus.ObjectPath) { exp.add(path, "playback", false) }
+
+// PlaybackStreamRemoved forgets the stream.
+func (exp *Exporter) PlaybackStreamRemoved(path dbus.ObjectPath) { exp.remove(path) }
+
+// DeviceVolumeUpdated updates the device volume.
+func (exp *Exporter) DeviceVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	exp.update(path, func(obj *object) { obj.volume = values })
+}
+
+// DeviceMuteUpdated updates the device mute state.
+func (exp *Exporter) DeviceMuteUpdated(path dbus.ObjectPath, mute bool) {
+	exp.update(path, func(obj *object) { obj.mute = mute })
+}
+
+// DeviceStateUpdated updates the device state.
+func (exp *Exporter) DeviceStateUpdated(path dbus.ObjectPath, state pulseaudio.DeviceState) {
+	exp.update(path, func(obj *object) { obj.state = state })
+}
+
+// DeviceActivePortUpdated updates the device active port.
+func (exp *Exporter) DeviceActivePortUpdated(path, port dbus.ObjectPath) {
+	name, _ := exp.pulse.DevicePort(port).Name()
+	exp.update(path, func(obj *object) { obj.port = name })
+}
+
+// StreamDeviceUpdated updates the stream device, when moved.
+func (exp *Exporter) StreamDeviceUpdated(path, device dbus.ObjectPath) {
+	exp.update(path, func(obj *object) { obj.dev = device })
+}
+
+// StreamVolumeUpdated updates the stream volume.
+func (exp *Exporter) StreamVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	exp.update(path, func(obj *object) { obj.volume = values })
+}
+
+// StreamMuteUpdated updates the stream mute state.
+func (exp *Exporter) StreamMuteUpdated(path dbus.ObjectPath, mute bool) {
+	exp.update(path, func(obj *object) { obj.mute = mute })
+}
+
+func (exp *Exporter) update(path dbus.ObjectPath, call func(*object)) {
+	exp.mu.Lock()
+	defer exp.mu.Unlock()
+	if obj, ok := exp.objects[path]; ok {
+		call(obj)
+	}
+}
+
+func (exp *Exporter) remove(path dbus.ObjectPath) {
+	exp.mu.Lock()
+	defer exp.mu.Unlock()
+	delete(exp.objects, path)
+}
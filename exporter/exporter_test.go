@@ -0,0 +1,42 @@
+package exporter
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	pulse := pulseaudio.NewReplayClient()
+	exp, e := New(pulse)
+	if e != nil {
+		t.Fatal("new:", e)
+	}
+	exp.objects["/sink0"] = &object{kind: "sink", device: true, labels: labels{{"type", "sink"}, {"name", "speakers"}}}
+	exp.objects["/sink1"] = &object{kind: "sink", device: true, labels: labels{{"type", "sink"}, {"name", "headset"}}}
+	exp.objects["/stream0"] = &object{kind: "playback", labels: labels{{"type", "playback"}, {"device", ""}}, dev: "/sink0"}
+
+	dispatch := func(name string, path dbus.ObjectPath, body ...interface{}) {
+		pulse.DispatchSignal(&dbus.Signal{Name: pulseaudio.DbusInterface + "." + name, Path: path, Body: body})
+	}
+	if got := exp.snapshot()["/stream0"].labels.get("device"); got != "speakers" {
+		t.Errorf("device label: got %q, want speakers", got)
+	}
+
+	dispatch("Stream.DeviceUpdated", "/stream0", dbus.ObjectPath("/sink1"))
+	dispatch("Stream.MuteUpdated", "/stream0", true)
+	dispatch("SinkRemoved", pulseaudio.DbusPath, dbus.ObjectPath("/sink0"))
+
+	objects := exp.snapshot()
+	if got := objects["/stream0"].labels.get("device"); got != "headset" {
+		t.Errorf("device label after move: got %q, want headset", got)
+	}
+	if !objects["/stream0"].mute {
+		t.Error("mute: not updated")
+	}
+	if _, ok := objects["/sink0"]; ok {
+		t.Error("removed sink still known")
+	}
+}
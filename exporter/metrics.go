@@ -0,0 +1,214 @@
+package exporter
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Metric types.
+const (
+	gauge   = "gauge"
+	counter = "counter"
+)
+
+// label is a metric label.
+//
+type label struct {
+	name, value string
+}
+
+// labels is an ordered list of metric labels.
+//
+type labels []label
+
+// with returns a copy of the labels with the label set. An existing label of
+// the same name is replaced.
+//
+func (lbls labels) with(name, value string) labels {
+	out := make(labels, 0, len(lbls)+1)
+	for _, lbl := range lbls {
+		if lbl.name != name {
+			out = append(out, lbl)
+		}
+	}
+	return append(out, label{name, value})
+}
+
+// get returns the value of a label, or an empty string.
+//
+func (lbls labels) get(name string) string {
+	for _, lbl := range lbls {
+		if lbl.name == name {
+			return lbl.value
+		}
+	}
+	return ""
+}
+
+// fromProperties returns a copy of the labels with values from a property list,
+// with labels sorted by name. Missing properties are set as empty labels, to
+// keep the same labels set for all objects of a metric.
+//
+func (lbls labels) fromProperties(names map[string]string, props pulseaudio.PropertyList) labels {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		lbls = lbls.with(name, props.String(names[name]))
+	}
+	return lbls
+}
+
+// String formats the labels as {name="value",...}.
+//
+func (lbls labels) String() string {
+	if len(lbls) == 0 {
+		return ""
+	}
+	strs := make([]string, len(lbls))
+	for i, lbl := range lbls {
+		strs[i] = lbl.name + `="` + escapeLabel(lbl.value) + `"`
+	}
+	return "{" + strings.Join(strs, ",") + "}"
+}
+
+// sample is a metric value with its labels.
+//
+type sample struct {
+	labels labels
+	value  float64
+}
+
+// family is a metric with all its samples.
+//
+type family struct {
+	name, typ, help string
+	samples         []sample
+}
+
+// metricSet collects the metrics of a scrape.
+//
+type metricSet struct {
+	families map[string]*family
+}
+
+func newMetricSet() *metricSet {
+	return &metricSet{families: make(map[string]*family)}
+}
+
+// add adds a sample to a metric. The type and help are set by the first sample.
+//
+func (set *metricSet) add(name, typ, help string, lbls labels, value float64) {
+	fam, ok := set.families[name]
+	if !ok {
+		fam = &family{name: name, typ: typ, help: help}
+		set.families[name] = fam
+	}
+	fam.samples = append(fam.samples, sample{lbls, value})
+}
+
+// writeTo writes the metrics in the Prometheus text format, sorted by name
+// and labels to get a stable output.
+//
+func (set *metricSet) writeTo(w io.Writer) (int64, error) {
+	names := make([]string, 0, len(set.families))
+	for name := range set.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cw := &countWriter{w: w}
+	buf := bufio.NewWriter(cw)
+	for _, name := range names {
+		fam := set.families[name]
+		fmt.Fprintf(buf, "# HELP %s %s\n", fam.name, escapeHelp(fam.help))
+		fmt.Fprintf(buf, "# TYPE %s %s\n", fam.name, fam.typ)
+
+		lines := make([]string, len(fam.samples))
+		for i, s := range fam.samples {
+			lines[i] = fam.name + s.labels.String() + " " + formatValue(s.value) + "\n"
+		}
+		sort.Strings(lines)
+		for _, line := range lines {
+			buf.WriteString(line)
+		}
+	}
+	e := buf.Flush()
+	return cw.n, e
+}
+
+// countWriter counts the bytes written.
+//
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, e := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, e
+}
+
+//
+//-----------------------------------------------------------------[ FORMAT ]--
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(str string) string { return labelEscaper.Replace(str) }
+
+func escapeHelp(str string) string { return helpEscaper.Replace(str) }
+
+// formatValue formats a sample value, with the Prometheus special values.
+//
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func formatUint(value uint64) string { return strconv.FormatUint(value, 10) }
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// lowerFirst returns the string with its first letter lowered.
+//
+func lowerFirst(str string) string {
+	if str == "" {
+		return str
+	}
+	return strings.ToLower(str[:1]) + str[1:]
+}
+
+// splitKey splits a stream count key (type \0 application).
+//
+func splitKey(key string) (kind, app string) {
+	parts := strings.SplitN(key, "\x00", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,49 @@
+package exporter
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestMetricSet(t *testing.T) {
+	set := newMetricSet()
+	sink := labels{{"type", "sink"}, {"name", `a"b\c`}}
+	set.add("test_volume", gauge, "Volume.\nRatio.", sink.with("channel", "1"), 0.5)
+	set.add("test_volume", gauge, "", sink.with("channel", "0"), 1)
+	set.add("test_up", gauge, "Up.", nil, math.NaN())
+
+	var buf bytes.Buffer
+	n, e := set.writeTo(&buf)
+	if e != nil || n != int64(buf.Len()) {
+		t.Fatalf("write: %d bytes, %v", n, e)
+	}
+	want := `# HELP test_up Up.
+# TYPE test_up gauge
+test_up NaN
+# HELP test_volume Volume.\nRatio.
+# TYPE test_volume gauge
+test_volume{type="sink",name="a\"b\\c",channel="0"} 1
+test_volume{type="sink",name="a\"b\\c",channel="1"} 0.5
+`
+	if buf.String() != want {
+		t.Errorf("write: got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestLabelsFromProperties(t *testing.T) {
+	props := pulseaudio.PropertyList{}
+	props.SetString(pulseaudio.PropApplicationName, "player\nX")
+	lbls := labels{{"type", "playback"}, {"device", "old"}}.with("device", "new")
+	lbls = lbls.fromProperties(DefaultStreamLabels, props)
+
+	want := `{type="playback",device="new",application="player\nX",role=""}`
+	if got := lbls.String(); got != want {
+		t.Errorf("labels: got %s, want %s", got, want)
+	}
+	if got := lbls.get("application"); got != "player\nX" {
+		t.Errorf("get application: got %q", got)
+	}
+}
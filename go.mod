@@ -0,0 +1,5 @@
+module github.com/sqp/pulseaudio
+
+go 1.18
+
+require github.com/godbus/dbus v0.0.0-20181101234600-2ff6f7ffd60f
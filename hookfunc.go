@@ -0,0 +1,143 @@
+package pulseaudio
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Handle references a function registered for a signal with Client.On or
+// Subscribe. Give it to Client.Unregister to remove the function.
+//
+type Handle struct {
+	name string
+	call func(Msg)
+}
+
+// Name returns the signal name of the handle.
+//
+func (h *Handle) Name() string { return h.name }
+
+// funcCall returns a callback forwarding the signal to the function. The data
+// is decoded by the event definition, and the payload fields are given as
+// arguments, like the On... interface method receives them.
+//
+func funcCall(def EventDef, fn reflect.Value) func(Msg) {
+	return func(m Msg) {
+		ev, e := def.Decode(m)
+		if e != nil {
+			return // Data doesn't match the signal definition.
+		}
+		payload := reflect.ValueOf(ev)
+		args := make([]reflect.Value, payload.NumField())
+		for i := range args {
+			args[i] = payload.Field(i)
+		}
+		fn.Call(args)
+	}
+}
+
+// payloadMatches returns whether the payload fields are the function
+// arguments, in order.
+//
+func payloadMatches(payload, fn reflect.Type) bool {
+	if payload.Kind() != reflect.Struct || payload.NumField() != fn.NumIn() {
+		return false
+	}
+	for i := 0; i < fn.NumIn(); i++ {
+		if payload.Field(i).Type != fn.In(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// On registers a function to receive a signal. The function must have the
+// same arguments as the method of the signal On... interface. The signal data
+// is decoded by the event definition, like for Subscribe.
+//
+//   pulse.On("Device.VolumeUpdated", func(path dbus.ObjectPath, values []uint32) {
+//   	fmt.Println("volume", path, values)
+//   })
+//
+// The handle returned can be given to Unregister to remove the function.
+// Signals are listened while at least one object or function is registered.
+//
+func (pulse *Client) On(name string, fn interface{}) (*Handle, error) {
+	h, tolisten, e := pulse.hooker.On(name, fn)
+	if e != nil {
+		return nil, e
+	}
+	return h, pulse.listenHandle(tolisten)
+}
+
+// listenHandle listens to the events of a new handle.
+//
+func (pulse *Client) listenHandle(tolisten []string) error {
+	if pulse.conn == nil {
+		return nil // Replay client.
+	}
+	for _, name := range tolisten {
+		e := pulse.ListenForSignal(name)
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// On registers a function to receive an event. The function must match the
+// method of the event interface type.
+//
+// tolisten contains the event name if it's the first client registered for
+// this event.
+//
+func (hook Hooker) On(name string, fn interface{}) (h *Handle, tolisten []string, e error) {
+	modelType, ok := hook.Types[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("hook %s: unknown event", name)
+	}
+	var def EventDef
+	for _, test := range hook.Events[name] {
+		if test.Type == modelType {
+			def = test
+			break
+		}
+	}
+	if def.Decode == nil {
+		return nil, nil, fmt.Errorf("hook %s: no event definition", name)
+	}
+	if modelType.NumMethod() != 1 {
+		return nil, nil, fmt.Errorf("hook %s: interface %s must have one method", name, modelType)
+	}
+	want := modelType.Method(0).Type
+	rv := reflect.ValueOf(fn)
+	if !rv.IsValid() || rv.Type() != want {
+		return nil, nil, fmt.Errorf("hook %s: got %T, want %s", name, fn, want)
+	}
+	if !payloadMatches(def.Payload, want) {
+		return nil, nil, fmt.Errorf("hook %s: payload %s doesn't match %s", name, def.Payload, want)
+	}
+
+	h, tolisten = hook.addHandle(name, funcCall(def, rv))
+	return h, tolisten, nil
+}
+
+// addHandle registers a callback for an event.
+//
+func (hook Hooker) addHandle(name string, call func(Msg)) (h *Handle, tolisten []string) {
+	h = &Handle{name: name, call: call}
+	hook.Hooks[name] = append(hook.Hooks[name], h)
+	if len(hook.Hooks[name]) == 1 { // First client registered for this event. need to listen.
+		tolisten = append(tolisten, name)
+	}
+	return h, tolisten
+}
+
+// callFor returns the callback to use for a registered client.
+//
+func (hook Hooker) callFor(name string, obj interface{}) func(Msg) {
+	if h, ok := obj.(*Handle); ok {
+		return h.call
+	}
+	return hook.Calls[name]
+}
@@ -0,0 +1,134 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"reflect"
+	"testing"
+)
+
+func TestClientOn(t *testing.T) {
+	pulse := pulseaudio.NewReplayClient()
+
+	var volumes [][]uint32
+	var states []pulseaudio.DeviceState
+	var sinks, sources []dbus.ObjectPath
+	hVolume, e := pulse.On("Device.VolumeUpdated", func(path dbus.ObjectPath, values []uint32) { volumes = append(volumes, values) })
+	if e != nil {
+		t.Fatal("on volume:", e)
+	}
+	_, e = pulse.On("Device.StateUpdated", func(path dbus.ObjectPath, state pulseaudio.DeviceState) { states = append(states, state) })
+	if e != nil {
+		t.Fatal("on state:", e)
+	}
+	_, e = pulse.On("NewSink", func(path dbus.ObjectPath) { sinks = append(sinks, path) })
+	if e != nil {
+		t.Fatal("on sink:", e)
+	}
+	_, e = pulse.On("NewSource", func(path dbus.ObjectPath) { sources = append(sources, path) })
+	if e != nil {
+		t.Fatal("on source:", e)
+	}
+
+	for _, test := range []struct {
+		name string
+		fn   interface{}
+	}{
+		{"Device.Unknown", func(dbus.ObjectPath) {}},
+		{"Device.VolumeUpdated", func(dbus.ObjectPath, []int) {}},
+		{"NewSink", func(dbus.ObjectPath, bool) {}},
+		{"NewSink", nil},
+	} {
+		if _, e := pulse.On(test.name, test.fn); e == nil {
+			t.Errorf("on %s %T: want an error", test.name, test.fn)
+		}
+	}
+
+	dispatch := func(name string, path dbus.ObjectPath, body ...interface{}) {
+		pulse.DispatchSignal(&dbus.Signal{Name: pulseaudio.DbusInterface + "." + name, Path: path, Body: body})
+	}
+	dispatch("Device.VolumeUpdated", "/sink0", []uint32{42})
+	dispatch("Device.StateUpdated", "/sink0", uint32(pulseaudio.StateIdle))
+	dispatch("NewSink", pulseaudio.DbusPath, dbus.ObjectPath("/sink1"))
+	dispatch("NewSource", pulseaudio.DbusPath, dbus.ObjectPath("/source1"))
+
+	pulse.Unregister(hVolume)
+	dispatch("Device.VolumeUpdated", "/sink0", []uint32{43})
+
+	if want := [][]uint32{{42}}; !reflect.DeepEqual(volumes, want) {
+		t.Errorf("volumes: got %v, want %v", volumes, want)
+	}
+	if want := []pulseaudio.DeviceState{pulseaudio.StateIdle}; !reflect.DeepEqual(states, want) {
+		t.Errorf("states: got %v, want %v", states, want)
+	}
+	if want := []dbus.ObjectPath{pulseaudio.DbusPath}; !reflect.DeepEqual(sinks, want) { // Like the NewSink method.
+		t.Errorf("sinks: got %v, want %v", sinks, want)
+	}
+	if want := []dbus.ObjectPath{"/source1"}; !reflect.DeepEqual(sources, want) {
+		t.Errorf("sources: got %v, want %v", sources, want)
+	}
+}
+
+func TestHookerOnListen(t *testing.T) {
+	hook := pulseaudio.NewHooker()
+	hook.AddEvents(pulseaudio.PulseEvents)
+
+	rec := &volumeRecorder{volumes: make(map[dbus.ObjectPath][][]uint32)}
+	if tolisten := hook.Register(rec); len(tolisten) != 2 {
+		t.Errorf("register: got tolisten %v, want 2 events", tolisten)
+	}
+	h, tolisten, e := hook.On("Device.VolumeUpdated", func(dbus.ObjectPath, []uint32) {})
+	if e != nil || len(tolisten) != 0 {
+		t.Errorf("on: got tolisten %v (error %v), want none", tolisten, e)
+	}
+	if tounlisten := hook.Unregister(rec); len(tounlisten) != 1 || tounlisten[0] != "SinkRemoved" {
+		t.Errorf("unregister object: got tounlisten %v, want [SinkRemoved]", tounlisten)
+	}
+	if tounlisten := hook.Unregister(h); len(tounlisten) != 1 || tounlisten[0] != "Device.VolumeUpdated" {
+		t.Errorf("unregister handle: got tounlisten %v, want [Device.VolumeUpdated]", tounlisten)
+	}
+}
+
+// swappedEvent has its fields in another order than the method arguments.
+type swappedEvent struct {
+	Value string
+	Path  dbus.ObjectPath
+}
+
+func (swappedEvent) EventName() string { return "org.PulseAudio.Ext.Test.Updated" }
+
+func TestHookerOnDecode(t *testing.T) {
+	hook := pulseaudio.NewHooker()
+	hook.AddEvents(pulseaudio.Events{pulseaudio.DefineEvent(
+		func(m pulseaudio.Msg) (ev testEvent, e error) {
+			ev.Path = m.P
+			ev.Value, e = pulseaudio.MsgArg[string](m, 0)
+			return ev, e
+		},
+		func(o onTestEvent, ev testEvent) { o.TestUpdated(ev.Path, ev.Value) },
+	)})
+
+	var values []string
+	_, _, e := hook.On("org.PulseAudio.Ext.Test.Updated", func(path dbus.ObjectPath, value string) {
+		values = append(values, string(path)+"="+value)
+	})
+	if e != nil {
+		t.Fatal("on:", e)
+	}
+	hook.Call("org.PulseAudio.Ext.Test.Updated", &dbus.Signal{Path: "/test", Body: []interface{}{"value"}})
+	hook.Call("org.PulseAudio.Ext.Test.Updated", &dbus.Signal{Path: "/test", Body: []interface{}{uint32(1)}}) // Rejected by the decoder.
+	if want := []string{"/test=value"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("calls: got %v, want %v", values, want)
+	}
+
+	swapped := pulseaudio.NewHooker()
+	swapped.AddEvents(pulseaudio.Events{pulseaudio.DefineEvent(
+		func(m pulseaudio.Msg) (ev swappedEvent, e error) { return ev, nil },
+		func(o onTestEvent, ev swappedEvent) { o.TestUpdated(ev.Path, ev.Value) },
+	)})
+	if _, _, e := swapped.On("org.PulseAudio.Ext.Test.Updated", func(dbus.ObjectPath, string) {}); e == nil {
+		t.Error("on swapped payload: want an error")
+	}
+}
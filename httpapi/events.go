@@ -0,0 +1,190 @@
+package httpapi
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// KeepAlive is the interval of comments sent on idle events streams, to keep
+// proxies from closing the connection.
+var KeepAlive = 30 * time.Second
+
+// eventBuffer is the number of events queued for each stream. Events are
+// dropped for streams that don't read fast enough.
+const eventBuffer = 64
+
+// Event is the JSON representation of a pulseaudio event.
+//
+type Event struct {
+	Event string          `json:"event"`
+	Path  dbus.ObjectPath `json:"path,omitempty"`
+	Value interface{}     `json:"value,omitempty"`
+}
+
+// serveEvents streams events as server-sent events until the client leaves
+// or the handler is closed.
+//
+func (h *Handler) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, fmt.Errorf("streaming not supported"))
+		return
+	}
+
+	events := h.events.subscribe()
+	defer h.events.unsubscribe(events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(KeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			data, e := json.Marshal(ev)
+			if e != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Event, data)
+		}
+		flusher.Flush()
+	}
+}
+
+//
+//--------------------------------------------------------------[ EVENT HUB ]--
+
+// eventHub receives the pulseaudio events and forwards them to all streams.
+//
+type eventHub struct {
+	mu     sync.Mutex
+	subs   map[chan Event]struct{}
+	closed bool
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subs: make(map[chan Event]struct{})}
+}
+
+// subscribe returns a new events stream. The channel is closed if the hub is
+// closed.
+//
+func (hub *eventHub) subscribe() chan Event {
+	c := make(chan Event, eventBuffer)
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if hub.closed {
+		close(c)
+		return c
+	}
+	hub.subs[c] = struct{}{}
+	return c
+}
+
+// unsubscribe removes an events stream.
+//
+func (hub *eventHub) unsubscribe(c chan Event) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if _, ok := hub.subs[c]; ok {
+		delete(hub.subs, c)
+		close(c)
+	}
+}
+
+// close ends all events streams.
+//
+func (hub *eventHub) close() {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	for c := range hub.subs {
+		close(c)
+	}
+	hub.subs = make(map[chan Event]struct{})
+	hub.closed = true
+}
+
+// send forwards an event to all streams, without blocking.
+//
+func (hub *eventHub) send(name string, path dbus.ObjectPath, value interface{}) {
+	ev := Event{Event: name, Path: path, Value: value}
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	for c := range hub.subs {
+		select {
+		case c <- ev:
+		default:
+		}
+	}
+}
+
+//
+//--------------------------------------------------------[ PULSE CALLBACKS ]--
+
+func (hub *eventHub) FallbackSinkUpdatedPath(path dbus.ObjectPath) {
+	hub.send("FallbackSinkUpdated", path, nil)
+}
+
+func (hub *eventHub) FallbackSinkUnset() {
+	hub.send("FallbackSinkUnset", "", nil)
+}
+
+func (hub *eventHub) NewSinkPath(path dbus.ObjectPath) {
+	hub.send("NewSink", path, nil)
+}
+
+func (hub *eventHub) SinkRemovedPath(path dbus.ObjectPath) {
+	hub.send("SinkRemoved", path, nil)
+}
+
+func (hub *eventHub) NewPlaybackStream(path dbus.ObjectPath) {
+	hub.send("NewPlaybackStream", path, nil)
+}
+
+func (hub *eventHub) PlaybackStreamRemoved(path dbus.ObjectPath) {
+	hub.send("PlaybackStreamRemoved", path, nil)
+}
+
+func (hub *eventHub) DeviceVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	hub.send("DeviceVolumeUpdated", path, values)
+}
+
+func (hub *eventHub) DeviceMuteUpdated(path dbus.ObjectPath, mute bool) {
+	hub.send("DeviceMuteUpdated", path, mute)
+}
+
+func (hub *eventHub) DeviceActivePortUpdated(path, port dbus.ObjectPath) {
+	hub.send("DeviceActivePortUpdated", path, port)
+}
+
+func (hub *eventHub) DeviceStateUpdated(path dbus.ObjectPath, state pulseaudio.DeviceState) {
+	hub.send("DeviceStateUpdated", path, state.String())
+}
+
+func (hub *eventHub) StreamVolumeUpdated(path dbus.ObjectPath, values []uint32) {
+	hub.send("StreamVolumeUpdated", path, values)
+}
+
+func (hub *eventHub) StreamMuteUpdated(path dbus.ObjectPath, mute bool) {
+	hub.send("StreamMuteUpdated", path, mute)
+}
@@ -0,0 +1,323 @@
+// Package httpapi exposes a pulseaudio server over HTTP with JSON.
+//
+// The Handler serves REST endpoints to list and modify devices, streams and
+// cards, and a server-sent events endpoint streaming the pulseaudio events.
+//
+// Endpoints, relative to the handler mount point:
+//   GET   /sinks               List sinks.
+//   GET   /sources             List sources.
+//   GET   /playback            List playback streams.
+//   GET   /record              List record streams.
+//   GET   /cards               List cards.
+//   GET   /{type}/{id}         Get one object by index (or name for devices and cards).
+//   PATCH /sinks/{id}          Change a device with a DevicePatch.
+//   PATCH /sources/{id}        Change a device with a DevicePatch.
+//   PATCH /playback/{id}       Change a stream with a StreamPatch.
+//   PATCH /record/{id}         Change a stream with a StreamPatch.
+//   PATCH /cards/{id}          Change a card with a CardPatch.
+//   GET   /events              Stream events as server-sent events.
+//
+// A PATCH returns the updated object, its body is limited to 1 MiB. Errors are
+// returned with a JSON Error: 404 for missing objects, 500 for other server
+// errors.
+//
+// Events are sent with the event name as SSE event type and an Event as JSON
+// data. The client must be listening for events, see pulseaudio.Client.Listen.
+//
+// Usage:
+//   pulse, e := pulseaudio.New()
+//   ...
+//   api, e := httpapi.New(pulse)
+//   ...
+//   go pulse.Listen()
+//   http.Handle("/audio/", http.StripPrefix("/audio", api))
+//
+package httpapi
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is returned when the requested object doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// notFoundError is the Dbus error name of missing objects.
+const notFoundError = pulseaudio.DbusInterface + ".NotFoundError"
+
+// maxBodySize is the maximum size of a request body.
+const maxBodySize = 1 << 20
+
+// Error is the JSON body of an error response.
+//
+type Error struct {
+	Error string `json:"error"`
+}
+
+// kind defines a type of object served by the API.
+//
+type kind struct {
+	list   string // Core property listing objects.
+	byName string // Core method to find an object by name, if any.
+}
+
+// kinds defines the objects served by the API, by URL name.
+var kinds = map[string]kind{
+	"sinks":    {list: "Sinks", byName: "GetSinkByName"},
+	"sources":  {list: "Sources", byName: "GetSourceByName"},
+	"playback": {list: "PlaybackStreams"},
+	"record":   {list: "RecordStreams"},
+	"cards":    {list: "Cards", byName: "GetCardByName"},
+}
+
+// Handler serves the pulseaudio API over HTTP.
+//
+type Handler struct {
+	pulse  *pulseaudio.Client
+	events *eventHub
+}
+
+// New creates an HTTP handler for the pulseaudio client, and registers it to
+// receive the events.
+//
+func New(pulse *pulseaudio.Client) (*Handler, error) {
+	h := &Handler{
+		pulse:  pulse,
+		events: newEventHub(),
+	}
+	if errs := pulse.Register(h.events); len(errs) > 0 {
+		pulse.Unregister(h.events)
+		return nil, errs[0]
+	}
+	return h, nil
+}
+
+// Close unregisters the handler from the pulseaudio events and ends all events
+// streams.
+//
+func (h *Handler) Close() error {
+	errs := h.pulse.Unregister(h.events)
+	h.events.close()
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ServeHTTP routes the request to the matching endpoint.
+//
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	if len(parts) == 1 && parts[0] == "events" {
+		if !allowMethod(w, r, "GET") {
+			return
+		}
+		h.serveEvents(w, r)
+		return
+	}
+
+	kindName := parts[0]
+	if _, ok := kinds[kindName]; !ok || len(parts) > 2 {
+		writeError(w, ErrNotFound)
+		return
+	}
+
+	if len(parts) == 1 {
+		if allowMethod(w, r, "GET") {
+			h.serveList(w, kindName)
+		}
+		return
+	}
+
+	if !allowMethod(w, r, "GET", "PATCH") {
+		return
+	}
+	path, e := h.resolve(kindName, parts[1])
+	if e != nil {
+		writeError(w, e)
+		return
+	}
+
+	if r.Method == "PATCH" {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		if e = h.patch(kindName, path, r); e != nil {
+			writeError(w, e)
+			return
+		}
+	}
+
+	obj, e := h.get(kindName, path)
+	if e != nil {
+		writeError(w, e)
+		return
+	}
+	writeJSON(w, http.StatusOK, obj)
+}
+
+// serveList writes all objects of a kind.
+//
+func (h *Handler) serveList(w http.ResponseWriter, kindName string) {
+	paths, e := h.pulse.Core().ListPath(kinds[kindName].list)
+	if e != nil {
+		writeError(w, e)
+		return
+	}
+	list := make([]interface{}, 0, len(paths))
+	for _, path := range paths {
+		obj, e := h.get(kindName, path)
+		if e != nil {
+			writeError(w, e)
+			return
+		}
+		list = append(list, obj)
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// resolve finds the path of an object by index, or by name for devices and
+// cards.
+//
+func (h *Handler) resolve(kindName, id string) (dbus.ObjectPath, error) {
+	k := kinds[kindName]
+	index, e := strconv.ParseUint(id, 10, 32)
+	if e != nil {
+		if k.byName == "" {
+			return "", ErrNotFound
+		}
+		var path dbus.ObjectPath
+		e = h.pulse.Core().Call(pulseaudio.DbusInterface+"."+k.byName, 0, id).Store(&path)
+		return path, e
+	}
+
+	paths, e := h.pulse.Core().ListPath(k.list)
+	if e != nil {
+		return "", e
+	}
+	for _, path := range paths {
+		idx, e := h.index(kindName, path)
+		switch {
+		case isNotFound(e): // Removed since the list query.
+		case e != nil:
+			return "", e
+		case uint64(idx) == index:
+			return path, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// index returns the index of an object.
+//
+func (h *Handler) index(kindName string, path dbus.ObjectPath) (uint32, error) {
+	switch kindName {
+	case "sinks", "sources":
+		return h.pulse.Device(path).Uint32("Index")
+	case "cards":
+		return h.pulse.Card(path).Uint32("Index")
+	}
+	return h.pulse.Stream(path).Uint32("Index")
+}
+
+// get returns the JSON representation of an object.
+//
+func (h *Handler) get(kindName string, path dbus.ObjectPath) (interface{}, error) {
+	switch kindName {
+	case "sinks", "sources":
+		return h.getDevice(kindName, path)
+	case "cards":
+		return h.getCard(path)
+	}
+	return h.getStream(path)
+}
+
+// patch applies the JSON patch of the request body to an object.
+//
+func (h *Handler) patch(kindName string, path dbus.ObjectPath, r *http.Request) error {
+	var patch interface{}
+	switch kindName {
+	case "sinks", "sources":
+		patch = &DevicePatch{}
+	case "cards":
+		patch = &CardPatch{}
+	default:
+		patch = &StreamPatch{}
+	}
+
+	if e := json.NewDecoder(r.Body).Decode(patch); e != nil {
+		return badRequest("invalid JSON: " + e.Error())
+	}
+
+	switch p := patch.(type) {
+	case *DevicePatch:
+		return h.patchDevice(kindName, path, p)
+	case *CardPatch:
+		return h.patchCard(path, p)
+	}
+	return h.patchStream(kindName, path, patch.(*StreamPatch))
+}
+
+//
+//------------------------------------------------------------------[ REPLY ]--
+
+// badRequest is an error caused by the client request.
+//
+type badRequest string
+
+func (e badRequest) Error() string { return string(e) }
+
+// allowMethod checks the request method, and writes a 405 error if it isn't
+// in the allowed list.
+//
+func allowMethod(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, method := range methods {
+		if r.Method == method {
+			return true
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeJSON(w, http.StatusMethodNotAllowed, Error{Error: "method not allowed"})
+	return false
+}
+
+// writeError writes an error with the matching status code.
+//
+func writeError(w http.ResponseWriter, e error) {
+	status := http.StatusInternalServerError
+	switch e.(type) {
+	case badRequest:
+		status = http.StatusBadRequest
+	}
+	if isNotFound(e) {
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, Error{Error: e.Error()})
+}
+
+// isNotFound returns whether the error is ErrNotFound, or the pulseaudio error
+// for a missing object. Other Dbus errors are server errors.
+//
+func isNotFound(e error) bool {
+	switch err := e.(type) {
+	case dbus.Error:
+		return err.Name == notFoundError
+	case *dbus.Error:
+		return err.Name == notFoundError
+	}
+	return e == ErrNotFound
+}
+
+// writeJSON writes a value as JSON.
+//
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,101 @@
+package httpapi
+
+import (
+	"github.com/godbus/dbus"
+
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRouteErrors(t *testing.T) {
+	h := &Handler{events: newEventHub()} // Routes tested don't use the client.
+	for _, test := range []struct {
+		method, path string
+		status       int
+	}{
+		{"GET", "/", http.StatusNotFound},
+		{"GET", "/unknown", http.StatusNotFound},
+		{"GET", "/sinks/0/extra", http.StatusNotFound},
+		{"POST", "/sinks", http.StatusMethodNotAllowed},
+		{"DELETE", "/cards/0", http.StatusMethodNotAllowed},
+		{"PATCH", "/events", http.StatusMethodNotAllowed},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(test.method, test.path, nil))
+		if rec.Code != test.status {
+			t.Errorf("%s %s: got status %d, want %d", test.method, test.path, rec.Code, test.status)
+		}
+		if !strings.Contains(rec.Body.String(), `"error"`) {
+			t.Errorf("%s %s: missing JSON error, got %q", test.method, test.path, rec.Body.String())
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	for _, test := range []struct {
+		e      error
+		status int
+	}{
+		{ErrNotFound, http.StatusNotFound},
+		{dbus.Error{Name: "org.PulseAudio.Core1.NotFoundError"}, http.StatusNotFound},
+		{&dbus.Error{Name: "org.PulseAudio.Core1.NotFoundError"}, http.StatusNotFound},
+		{dbus.Error{Name: "org.PulseAudio.Core1.AccessDeniedError"}, http.StatusInternalServerError},
+		{dbus.Error{Name: "org.freedesktop.DBus.Error.NoReply"}, http.StatusInternalServerError},
+		{badRequest("invalid"), http.StatusBadRequest},
+	} {
+		rec := httptest.NewRecorder()
+		writeError(rec, test.e)
+		if rec.Code != test.status {
+			t.Errorf("error %v: got status %d, want %d", test.e, rec.Code, test.status)
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	h := &Handler{events: newEventHub()}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	resp, e := http.Get(srv.URL + "/events")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type: got %q", ct)
+	}
+
+	// Wait for the stream to be subscribed before sending.
+	for {
+		h.events.mu.Lock()
+		n := len(h.events.subs)
+		h.events.mu.Unlock()
+		if n > 0 {
+			break
+		}
+	}
+	h.events.DeviceVolumeUpdated(dbus.ObjectPath("/sink0"), []uint32{100, 200})
+	h.events.close()
+
+	var lines []string
+	scan := bufio.NewScanner(resp.Body)
+	for scan.Scan() {
+		lines = append(lines, scan.Text())
+	}
+	want := []string{
+		"event: DeviceVolumeUpdated",
+		`data: {"event":"DeviceVolumeUpdated","path":"/sink0","value":[100,200]}`,
+		"",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("events: got %q, want %q", lines, want)
+	}
+
+	// Subscribing after close gets a closed stream.
+	if _, ok := <-h.events.subscribe(); ok {
+		t.Error("subscribe after close: expected a closed stream")
+	}
+}
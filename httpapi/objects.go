@@ -0,0 +1,267 @@
+package httpapi
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"strconv"
+)
+
+// Device is the JSON representation of a sink or source.
+//
+type Device struct {
+	Index       uint32   `json:"index"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Volume      []uint32 `json:"volume"`
+	Mute        bool     `json:"mute"`
+	State       string   `json:"state"`
+	Default     bool     `json:"default"`
+	Port        string   `json:"port,omitempty"`
+	Ports       []Port   `json:"ports,omitempty"`
+}
+
+// Port is the JSON representation of a device port.
+//
+type Port struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Available   string `json:"available"`
+}
+
+// Stream is the JSON representation of a playback or record stream.
+//
+type Stream struct {
+	Index       uint32   `json:"index"`
+	Application string   `json:"application"`
+	Media       string   `json:"media"`
+	Device      uint32   `json:"device"`
+	Volume      []uint32 `json:"volume,omitempty"`
+	Mute        bool     `json:"mute"`
+}
+
+// Card is the JSON representation of a card.
+//
+type Card struct {
+	Index    uint32    `json:"index"`
+	Name     string    `json:"name"`
+	Profile  string    `json:"profile"`
+	Profiles []Profile `json:"profiles"`
+}
+
+// Profile is the JSON representation of a card profile.
+//
+type Profile struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// DevicePatch defines the changes to apply to a device. Only set fields are
+// applied.
+//
+type DevicePatch struct {
+	Volume  []uint32 `json:"volume,omitempty"`
+	Mute    *bool    `json:"mute,omitempty"`
+	Port    *string  `json:"port,omitempty"`    // Port name.
+	Default *bool    `json:"default,omitempty"` // Only true is valid.
+}
+
+// StreamPatch defines the changes to apply to a stream. Only set fields are
+// applied.
+//
+type StreamPatch struct {
+	Volume []uint32 `json:"volume,omitempty"`
+	Mute   *bool    `json:"mute,omitempty"`
+	Device *uint32  `json:"device,omitempty"` // Index of the device to move to.
+}
+
+// CardPatch defines the changes to apply to a card.
+//
+type CardPatch struct {
+	Profile *string `json:"profile,omitempty"` // Profile name.
+}
+
+// Availability names, indexed by pulseaudio.PortAvailability.
+var availabilityNames = []string{"unknown", "no", "yes"}
+
+//
+//-----------------------------------------------------------------[ DEVICE ]--
+
+func (h *Handler) getDevice(kindName string, path dbus.ObjectPath) (*Device, error) {
+	dev := h.pulse.TypedDevice(path)
+	var e error
+	out := &Device{}
+	if out.Index, e = dev.Uint32("Index"); e != nil {
+		return nil, e
+	}
+	if out.Name, e = dev.Name(); e != nil {
+		return nil, e
+	}
+	if out.Volume, e = dev.ListUint32("Volume"); e != nil {
+		return nil, e
+	}
+	if out.Mute, e = dev.Bool("Mute"); e != nil {
+		return nil, e
+	}
+	if props, e := dev.PropertyList(); e == nil {
+		out.Description = props.DeviceDescription()
+	}
+	if state, e := dev.State(); e == nil {
+		out.State = state.String()
+	}
+	if fallback, e := h.pulse.Core().ObjectPath(fallbackProperty(kindName)); e == nil {
+		out.Default = fallback == path
+	}
+
+	// Ports are optional.
+	if port, e := dev.ActivePort(); e == nil {
+		out.Port, _ = port.Name()
+	}
+	ports, _ := dev.Ports()
+	for _, port := range ports {
+		var p Port
+		p.Name, _ = port.Name()
+		p.Description, _ = port.Description()
+		if avail, e := port.Available(); e == nil && int(avail) < len(availabilityNames) {
+			p.Available = availabilityNames[avail]
+		}
+		out.Ports = append(out.Ports, p)
+	}
+	return out, nil
+}
+
+func (h *Handler) patchDevice(kindName string, path dbus.ObjectPath, patch *DevicePatch) error {
+	dev := h.pulse.TypedDevice(path)
+	if patch.Default != nil && !*patch.Default {
+		return badRequest("default can only be set to true")
+	}
+
+	if patch.Port != nil {
+		var portPath dbus.ObjectPath
+		e := dev.Call(pulseaudio.DbusInterface+".Device.GetPortByName", 0, *patch.Port).Store(&portPath)
+		if e != nil {
+			return badRequest("unknown port " + *patch.Port)
+		}
+		if e = dev.SetActivePort(portPath); e != nil {
+			return e
+		}
+	}
+	if patch.Volume != nil {
+		if e := dev.Set("Volume", patch.Volume); e != nil {
+			return e
+		}
+	}
+	if patch.Mute != nil {
+		if e := dev.Set("Mute", *patch.Mute); e != nil {
+			return e
+		}
+	}
+	if patch.Default != nil {
+		return h.pulse.Core().Set(fallbackProperty(kindName), path)
+	}
+	return nil
+}
+
+// fallbackProperty returns the Core property of the default device of a kind.
+//
+func fallbackProperty(kindName string) string {
+	if kindName == "sources" {
+		return "FallbackSource"
+	}
+	return "FallbackSink"
+}
+
+//
+//-----------------------------------------------------------------[ STREAM ]--
+
+func (h *Handler) getStream(path dbus.ObjectPath) (*Stream, error) {
+	stream := h.pulse.TypedStream(path)
+	var e error
+	out := &Stream{}
+	if out.Index, e = stream.Uint32("Index"); e != nil {
+		return nil, e
+	}
+	if props, e := stream.PropertyList(); e == nil {
+		out.Application = props.ApplicationName()
+		out.Media = props.MediaName()
+	}
+	if devpath, e := stream.Device(); e == nil {
+		out.Device, _ = h.pulse.Device(devpath).Uint32("Index")
+	}
+
+	// Record streams have no volume and mute.
+	out.Volume, _ = stream.ListUint32("Volume")
+	out.Mute, _ = stream.Bool("Mute")
+	return out, nil
+}
+
+func (h *Handler) patchStream(kindName string, path dbus.ObjectPath, patch *StreamPatch) error {
+	stream := h.pulse.TypedStream(path)
+	if patch.Device != nil {
+		devKind := "sinks"
+		if kindName == "record" {
+			devKind = "sources"
+		}
+		devpath, e := h.resolve(devKind, strconv.FormatUint(uint64(*patch.Device), 10))
+		if e == ErrNotFound {
+			return badRequest("unknown device")
+		}
+		if e != nil {
+			return e
+		}
+		if e = stream.Move(devpath); e != nil {
+			return e
+		}
+	}
+	if patch.Volume != nil {
+		if e := stream.Set("Volume", patch.Volume); e != nil {
+			return e
+		}
+	}
+	if patch.Mute != nil {
+		return stream.Set("Mute", *patch.Mute)
+	}
+	return nil
+}
+
+//
+//-------------------------------------------------------------------[ CARD ]--
+
+func (h *Handler) getCard(path dbus.ObjectPath) (*Card, error) {
+	card := h.pulse.Card(path)
+	var e error
+	out := &Card{Profiles: []Profile{}}
+	if out.Index, e = card.Uint32("Index"); e != nil {
+		return nil, e
+	}
+	if out.Name, e = card.Name(); e != nil {
+		return nil, e
+	}
+	if active, e := card.ActiveProfile(); e == nil {
+		out.Profile, _ = active.Name()
+	}
+	profiles, e := card.Profiles()
+	if e != nil {
+		return nil, e
+	}
+	for _, profile := range profiles {
+		var p Profile
+		p.Name, _ = profile.Name()
+		p.Description, _ = profile.Description()
+		out.Profiles = append(out.Profiles, p)
+	}
+	return out, nil
+}
+
+func (h *Handler) patchCard(path dbus.ObjectPath, patch *CardPatch) error {
+	if patch.Profile == nil {
+		return nil
+	}
+	card := h.pulse.Card(path)
+	profile, e := card.ProfileByName(*patch.Profile)
+	if e != nil {
+		return badRequest("unknown profile " + *patch.Profile)
+	}
+	return card.SetActiveProfile(profile.Path())
+}
@@ -0,0 +1,215 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"sync"
+	"time"
+)
+
+// IdleSuspender is an opt-in policy that suspends sinks when they have been
+// idle (no stream playing) for some time, and resumes them when a new playback
+// stream needs them.
+//
+// Sinks suspended with Device.Suspend are not resumed automatically by the
+// server, so the policy watches new playback streams to resume the devices it
+// suspended itself.
+//
+//   idle := pulseaudio.NewIdleSuspender(pulse, 5*time.Minute)
+//   idle.Filter = func(dev *pulseaudio.Device) bool {
+//   	name, _ := dev.Name()
+//   	return strings.Contains(name, "hdmi")
+//   }
+//   idle.Start()
+//   defer idle.Stop()
+//
+type IdleSuspender struct {
+	Timeout time.Duration      // Idle time before a device is suspended.
+	Filter  func(*Device) bool // Select devices managed by the policy. Optional, default all sinks.
+	OnError func(error)        // Error logger callback. Optional.
+
+	pulse     *Client
+	managed   map[dbus.ObjectPath]bool        // Filter results by device.
+	timers    map[dbus.ObjectPath]*time.Timer // Pending suspend by device.
+	suspended map[dbus.ObjectPath]bool        // Devices suspended by the policy.
+	mu        sync.Mutex
+}
+
+// NewIdleSuspender creates a policy to suspend sinks after timeout.
+//
+func NewIdleSuspender(pulse *Client, timeout time.Duration) *IdleSuspender {
+	return &IdleSuspender{
+		Timeout:   timeout,
+		pulse:     pulse,
+		managed:   make(map[dbus.ObjectPath]bool),
+		timers:    make(map[dbus.ObjectPath]*time.Timer),
+		suspended: make(map[dbus.ObjectPath]bool),
+	}
+}
+
+// Start registers the policy to the client events and starts the timers of
+// sinks already idle.
+//
+func (is *IdleSuspender) Start() (errs []error) {
+	errs = is.pulse.Register(is)
+
+	sinks, e := is.pulse.Core().ListPath("Sinks")
+	if e != nil {
+		return append(errs, e)
+	}
+	for _, sink := range sinks {
+		state, e := is.pulse.TypedDevice(sink).State()
+		if e != nil {
+			errs = append(errs, e)
+			continue
+		}
+		is.DeviceStateUpdated(sink, state)
+	}
+	return errs
+}
+
+// Stop unregisters the policy and cancels pending suspends.
+// Devices already suspended are left as is.
+//
+func (is *IdleSuspender) Stop() (errs []error) {
+	errs = is.pulse.Unregister(is)
+
+	is.mu.Lock()
+	for path, timer := range is.timers {
+		timer.Stop()
+		delete(is.timers, path)
+	}
+	is.mu.Unlock()
+	return errs
+}
+
+// DeviceStateUpdated is called when the state has changed on a device.
+//
+func (is *IdleSuspender) DeviceStateUpdated(path dbus.ObjectPath, state DeviceState) {
+	if !is.isManaged(path) {
+		return
+	}
+
+	is.mu.Lock()
+	defer is.mu.Unlock()
+	switch state {
+	case StateIdle:
+		delete(is.suspended, path)
+		is.armTimer(path)
+
+	case StateRunning:
+		is.stopTimer(path)
+		delete(is.suspended, path)
+
+	case StateSuspended:
+		is.stopTimer(path)
+	}
+}
+
+// NewPlaybackStream is called when a playback stream is added.
+// Resumes its device if it was suspended by the policy.
+//
+func (is *IdleSuspender) NewPlaybackStream(path dbus.ObjectPath) {
+	dev, e := is.pulse.Stream(path).ObjectPath("Device")
+	if e != nil {
+		is.logError(e)
+		return
+	}
+	if !is.isManaged(dev) {
+		return
+	}
+
+	is.mu.Lock()
+	is.stopTimer(dev)
+	resume := is.suspended[dev]
+	delete(is.suspended, dev)
+	is.mu.Unlock()
+
+	if resume {
+		is.logError(is.pulse.TypedDevice(dev).Resume())
+	}
+}
+
+// SinkRemovedPath is called when a sink is removed.
+//
+func (is *IdleSuspender) SinkRemovedPath(path dbus.ObjectPath) {
+	is.mu.Lock()
+	is.stopTimer(path)
+	delete(is.managed, path)
+	delete(is.suspended, path)
+	is.mu.Unlock()
+}
+
+// SourceRemoved is called when a source is removed. Forget its filter result.
+//
+func (is *IdleSuspender) SourceRemoved(path dbus.ObjectPath) {
+	is.mu.Lock()
+	delete(is.managed, path)
+	is.mu.Unlock()
+}
+
+// isManaged returns whether the device is handled by the policy.
+// The filter result is cached.
+//
+func (is *IdleSuspender) isManaged(path dbus.ObjectPath) bool {
+	is.mu.Lock()
+	managed, ok := is.managed[path]
+	is.mu.Unlock()
+	if ok {
+		return managed
+	}
+
+	dev := is.pulse.TypedDevice(path)
+	managed = isSink(path) && (is.Filter == nil || is.Filter(dev))
+
+	is.mu.Lock()
+	is.managed[path] = managed
+	is.mu.Unlock()
+	return managed
+}
+
+// armTimer starts the suspend timer of the device. Must be locked.
+//
+func (is *IdleSuspender) armTimer(path dbus.ObjectPath) {
+	if _, ok := is.timers[path]; ok {
+		return
+	}
+	is.timers[path] = time.AfterFunc(is.Timeout, func() {
+		is.mu.Lock()
+		_, ok := is.timers[path]
+		delete(is.timers, path)
+		if ok {
+			is.suspended[path] = true
+		}
+		is.mu.Unlock()
+
+		if ok {
+			is.logError(is.pulse.TypedDevice(path).Suspend())
+		}
+	})
+}
+
+// stopTimer cancels the suspend timer of the device. Must be locked.
+//
+func (is *IdleSuspender) stopTimer(path dbus.ObjectPath) {
+	if timer, ok := is.timers[path]; ok {
+		timer.Stop()
+		delete(is.timers, path)
+	}
+}
+
+// logError forwards an error to the OnError callback if any.
+//
+func (is *IdleSuspender) logError(e error) {
+	if e != nil && is.OnError != nil {
+		is.OnError(e)
+	}
+}
+
+// isSink returns whether the device path references a sink.
+// Device paths are in the form /org/pulseaudio/core1/sinkX or sourceX.
+//
+func isSink(path dbus.ObjectPath) bool {
+	prefix := dbus.ObjectPath(DbusPath + "/sink")
+	return len(path) > len(prefix) && path[:len(prefix)] == prefix
+}
@@ -0,0 +1,96 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	idleSink0 = dbus.ObjectPath(pulseaudio.DbusPath + "/sink0")
+	idleSink1 = dbus.ObjectPath(pulseaudio.DbusPath + "/sink1")
+)
+
+// newIdleTest starts an idle suspender on a fake server with two idle sinks.
+func newIdleTest(t *testing.T, filter func(*pulseaudio.Device) bool) (*pulseaudio.Client, *fakeServer, *pulseaudio.IdleSuspender) {
+	pulse := pulseaudio.NewReplayClient()
+	fs := newFakeServer(pulse)
+	fs.set(pulseaudio.DbusPath, "Sinks", []dbus.ObjectPath{idleSink0, idleSink1})
+	fs.set(idleSink0, "Name", "speakers")
+	fs.set(idleSink0, "State", uint32(pulseaudio.StateIdle))
+	fs.set(idleSink1, "Name", "hdmi")
+	fs.set(idleSink1, "State", uint32(pulseaudio.StateIdle))
+
+	idle := pulseaudio.NewIdleSuspender(pulse, 30*time.Millisecond)
+	idle.Filter = filter
+	idle.OnError = func(e error) { t.Error("idle:", e) }
+	if errs := idle.Start(); len(errs) > 0 {
+		t.Fatal("start:", errs)
+	}
+	return pulse, fs, idle
+}
+
+func dispatchState(pulse *pulseaudio.Client, path dbus.ObjectPath, state pulseaudio.DeviceState) {
+	pulse.DispatchSignal(&dbus.Signal{Name: pulseaudio.DbusInterface + ".Device.StateUpdated", Path: path, Body: []interface{}{uint32(state)}})
+}
+
+func TestIdleSuspender(t *testing.T) {
+	pulse, fs, idle := newIdleTest(t, nil)
+	defer idle.Stop()
+
+	// sink1 is used before the timeout, only sink0 is suspended.
+	dispatchState(pulse, idleSink1, pulseaudio.StateRunning)
+	time.Sleep(80 * time.Millisecond)
+	if calls, want := fs.takeCalls(), []string{string(idleSink0) + " Suspend[true]"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("timeout: got calls %v, want %v", calls, want)
+	}
+
+	// A new stream on the suspended sink resumes it.
+	fs.set("/stream0", "Device", idleSink0)
+	pulse.DispatchSignal(&dbus.Signal{Name: pulseaudio.DbusInterface + ".NewPlaybackStream", Path: pulseaudio.DbusPath, Body: []interface{}{dbus.ObjectPath("/stream0")}})
+	if calls, want := fs.takeCalls(), []string{string(idleSink0) + " Suspend[false]"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("resume: got calls %v, want %v", calls, want)
+	}
+
+	// A stream on a device not suspended by the policy is left as is.
+	fs.set("/stream1", "Device", idleSink1)
+	pulse.DispatchSignal(&dbus.Signal{Name: pulseaudio.DbusInterface + ".NewPlaybackStream", Path: pulseaudio.DbusPath, Body: []interface{}{dbus.ObjectPath("/stream1")}})
+	if calls := fs.takeCalls(); len(calls) != 0 {
+		t.Errorf("not suspended: got calls %v, want none", calls)
+	}
+
+	// Stop cancels the pending suspends.
+	dispatchState(pulse, idleSink1, pulseaudio.StateIdle)
+	idle.Stop()
+	time.Sleep(80 * time.Millisecond)
+	if calls := fs.takeCalls(); len(calls) != 0 {
+		t.Errorf("stopped: got calls %v, want none", calls)
+	}
+}
+
+func TestIdleSuspenderFilter(t *testing.T) {
+	var filtered []dbus.ObjectPath
+	_, fs, idle := newIdleTest(t, func(dev *pulseaudio.Device) bool {
+		filtered = append(filtered, dev.Path())
+		name, _ := dev.Name()
+		return strings.Contains(name, "hdmi")
+	})
+	defer idle.Stop()
+
+	time.Sleep(80 * time.Millisecond)
+	if calls, want := fs.takeCalls(), []string{string(idleSink1) + " Suspend[true]"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %v, want %v", calls, want)
+	}
+
+	// Filter results are cached, sources are never managed.
+	idle.DeviceStateUpdated(idleSink0, pulseaudio.StateIdle)
+	idle.DeviceStateUpdated(pulseaudio.DbusPath+"/source0", pulseaudio.StateIdle)
+	if want := []dbus.ObjectPath{idleSink0, idleSink1}; !reflect.DeepEqual(filtered, want) {
+		t.Errorf("filtered: got %v, want %v", filtered, want)
+	}
+}
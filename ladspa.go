@@ -0,0 +1,165 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LadspaInterface is the Dbus interface of the ladspa sink extension.
+// Requires module-ladspa-sink.
+//
+const LadspaInterface = "org.PulseAudio.Ext.Ladspa1"
+
+// Ladspa controls the plugin of a ladspa sink. The path is the sink path.
+//
+// Properties list:
+//   (adab)
+//     !AlgorithmParameters  RW  The plugin control values, and whether each of
+//                               them uses the plugin default. See LadspaParameters.
+//
+func (pulse *Client) Ladspa(sink dbus.ObjectPath) *Ladspa {
+	return &Ladspa{pulse.newObject(LadspaInterface, sink)}
+}
+
+// LadspaParameters defines the control values of a ladspa plugin.
+//
+type LadspaParameters struct {
+	Controls []float64 // Control values, in the plugin order.
+	Defaults []bool    // Whether each control uses the plugin default value instead.
+}
+
+// Equal returns whether both parameters lists are the same.
+//
+func (params LadspaParameters) Equal(other LadspaParameters) bool {
+	return reflect.DeepEqual(params, other)
+}
+
+// Ladspa is a typed access to the plugin of a ladspa sink.
+// See Client.Ladspa for the properties list.
+//
+type Ladspa struct {
+	*Object
+}
+
+// Parameters returns the plugin control values.
+//
+func (l *Ladspa) Parameters() (params LadspaParameters, e error) {
+	e = l.Get("AlgorithmParameters", &params)
+	return params, e
+}
+
+// SetParameters sets all the plugin control values.
+// Both lists must have the number of controls of the plugin.
+//
+func (l *Ladspa) SetParameters(params LadspaParameters) error {
+	if len(params.Controls) != len(params.Defaults) {
+		return fmt.Errorf("ladspa: %d controls and %d defaults", len(params.Controls), len(params.Defaults))
+	}
+	return l.Set("AlgorithmParameters", params)
+}
+
+// SetControl sets a single control value of the plugin.
+//
+func (l *Ladspa) SetControl(index int, value float64) error {
+	params, e := l.Parameters()
+	if e != nil {
+		return e
+	}
+	if index < 0 || index >= len(params.Controls) {
+		return fmt.Errorf("ladspa: control %d out of range (%d controls)", index, len(params.Controls))
+	}
+	params.Controls[index] = value
+	params.Defaults[index] = false
+	return l.SetParameters(params)
+}
+
+// Watch polls the plugin parameters at the given interval and calls onChange
+// when they are modified, by this client or another one. The module doesn't
+// emit Dbus signals for this property, so it can't use the hooks system.
+//
+// onChange is called once with the current parameters when the watch starts.
+// Errors are ignored, the sink may be temporarily unavailable.
+// Call the returned function to stop watching.
+//
+func (l *Ladspa) Watch(interval time.Duration, onChange func(LadspaParameters)) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last LadspaParameters
+		known := false
+		for {
+			if params, e := l.Parameters(); e == nil && (!known || !params.Equal(last)) {
+				last, known = params, true
+				onChange(params)
+			}
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+//
+//-------------------------------------------------------------[ LOAD MODULE ]--
+
+// LadspaSinkConfig defines the arguments to load a ladspa sink.
+//
+type LadspaSinkConfig struct {
+	SinkName string    // Name of the new sink. Optional.
+	Master   string    // Name of the sink to filter. Optional, default the fallback sink.
+	Plugin   string    // Plugin library name (ex: "sc4_1882").
+	Label    string    // Plugin label (ex: "sc4").
+	Control  []float64 // Control values. NaN values use the plugin default. Optional.
+
+	Args map[string]string // Other module arguments. Optional.
+}
+
+// Arguments returns the module arguments matching the config.
+//
+func (cfg LadspaSinkConfig) Arguments() map[string]string {
+	args := make(map[string]string, len(cfg.Args)+5)
+	for k, v := range cfg.Args {
+		args[k] = v
+	}
+	args["plugin"] = cfg.Plugin
+	args["label"] = cfg.Label
+	if cfg.SinkName != "" {
+		args["sink_name"] = cfg.SinkName
+	}
+	if cfg.Master != "" {
+		args["master"] = cfg.Master
+	}
+	if len(cfg.Control) > 0 {
+		ctrls := make([]string, len(cfg.Control))
+		for i, v := range cfg.Control {
+			if !math.IsNaN(v) {
+				ctrls[i] = strconv.FormatFloat(v, 'g', -1, 64)
+			}
+		}
+		args["control"] = strings.Join(ctrls, ",")
+	}
+	return args
+}
+
+// LoadLadspaSink loads a ladspa sink with the given config.
+// Use Ladspa with the new sink path to control the plugin, and Unload on the
+// module to remove the sink.
+//
+func (pulse *Client) LoadLadspaSink(cfg LadspaSinkConfig) (*Module, error) {
+	if cfg.Plugin == "" || cfg.Label == "" {
+		return nil, fmt.Errorf("ladspa: plugin and label are required")
+	}
+	return pulse.LoadModule("module-ladspa-sink", cfg.Arguments())
+}
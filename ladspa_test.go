@@ -0,0 +1,41 @@
+package pulseaudio_test
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLadspaSinkConfig(t *testing.T) {
+	cfg := pulseaudio.LadspaSinkConfig{
+		SinkName: "compressor",
+		Plugin:   "sc4_1882",
+		Label:    "sc4",
+		Control:  []float64{1, math.NaN(), -20, 0.5},
+		Args:     map[string]string{"sink_properties": "device.description=Compressor"},
+	}
+	want := map[string]string{
+		"sink_name":       "compressor",
+		"plugin":          "sc4_1882",
+		"label":           "sc4",
+		"control":         "1,,-20,0.5",
+		"sink_properties": "device.description=Compressor",
+	}
+	if got := cfg.Arguments(); !reflect.DeepEqual(got, want) {
+		t.Errorf("arguments: got %v, want %v", got, want)
+	}
+}
+
+func TestLadspaParameters(t *testing.T) {
+	var params pulseaudio.LadspaParameters
+	e := pulseaudio.StoreValue([]interface{}{[]float64{1, 2}, []bool{false, true}}, &params)
+	if e != nil {
+		t.Fatal("store parameters:", e)
+	}
+	want := pulseaudio.LadspaParameters{Controls: []float64{1, 2}, Defaults: []bool{false, true}}
+	if !params.Equal(want) {
+		t.Errorf("store parameters: got %+v, want %+v", params, want)
+	}
+}
@@ -0,0 +1,122 @@
+package pulseaudio
+
+import "time"
+
+// MemstatsPath is the Dbus path of the memory statistics object.
+//
+const MemstatsPath = DbusPath + "/memstats"
+
+// Memstats gives access to the server memory statistics.
+//
+// Properties list:
+//   Uint32
+//     !CurrentMemblocks          The number of memory blocks currently allocated.
+//     !CurrentMemblocksSize      The size of memory blocks currently allocated, in bytes.
+//     !AccumulatedMemblocks      The number of memory blocks allocated since the server start.
+//     !AccumulatedMemblocksSize  The size of memory blocks allocated since the server start, in bytes.
+//     !SampleCacheSize           The size of the sample cache, in bytes.
+//
+func (pulse *Client) Memstats() *Memstats {
+	return &Memstats{pulse.newObject(DbusInterface+".Memstats", MemstatsPath)}
+}
+
+// Memstats is a typed access to the server memory statistics.
+// See Client.Memstats for the properties list.
+//
+type Memstats struct {
+	*Object
+}
+
+// MemstatsSnapshot defines the memory statistics at a given time.
+//
+type MemstatsSnapshot struct {
+	Time                     time.Time
+	CurrentMemblocks         uint32
+	CurrentMemblocksSize     uint32
+	AccumulatedMemblocks     uint32
+	AccumulatedMemblocksSize uint32
+	SampleCacheSize          uint32
+}
+
+// MemstatsDelta defines the evolution of memory statistics between two
+// snapshots.
+//
+type MemstatsDelta struct {
+	Elapsed                  time.Duration
+	CurrentMemblocks         int64
+	CurrentMemblocksSize     int64
+	AccumulatedMemblocks     int64
+	AccumulatedMemblocksSize int64
+	SampleCacheSize          int64
+}
+
+// Snapshot queries all memory statistics.
+//
+func (ms *Memstats) Snapshot() (snap MemstatsSnapshot, e error) {
+	for name, dest := range map[string]*uint32{
+		"CurrentMemblocks":         &snap.CurrentMemblocks,
+		"CurrentMemblocksSize":     &snap.CurrentMemblocksSize,
+		"AccumulatedMemblocks":     &snap.AccumulatedMemblocks,
+		"AccumulatedMemblocksSize": &snap.AccumulatedMemblocksSize,
+		"SampleCacheSize":          &snap.SampleCacheSize,
+	} {
+		*dest, e = ms.Uint32(name)
+		if e != nil {
+			return snap, e
+		}
+	}
+	snap.Time = time.Now()
+	return snap, nil
+}
+
+// Sample queries the memory statistics at the given interval and calls
+// onSample with the new snapshot and its difference with the previous one.
+// The first call is made after the first interval.
+//
+// Errors are forwarded to onSample, the delta is then computed with the last
+// valid snapshot. Call the returned function to stop sampling.
+//
+func (ms *Memstats) Sample(interval time.Duration, onSample func(MemstatsSnapshot, MemstatsDelta, error)) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		last, lastErr := ms.Snapshot()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			snap, e := ms.Snapshot()
+			switch {
+			case e != nil:
+				onSample(snap, MemstatsDelta{}, e)
+				continue
+
+			case lastErr != nil: // No reference to compare yet.
+				onSample(snap, MemstatsDelta{}, nil)
+
+			default:
+				onSample(snap, snap.Delta(last), nil)
+			}
+			last, lastErr = snap, nil
+		}
+	}()
+	return func() { close(done) }
+}
+
+// Delta returns the difference between the snapshot and an older one.
+//
+func (snap MemstatsSnapshot) Delta(old MemstatsSnapshot) MemstatsDelta {
+	return MemstatsDelta{
+		Elapsed:                  snap.Time.Sub(old.Time),
+		CurrentMemblocks:         int64(snap.CurrentMemblocks) - int64(old.CurrentMemblocks),
+		CurrentMemblocksSize:     int64(snap.CurrentMemblocksSize) - int64(old.CurrentMemblocksSize),
+		AccumulatedMemblocks:     int64(snap.AccumulatedMemblocks) - int64(old.AccumulatedMemblocks),
+		AccumulatedMemblocksSize: int64(snap.AccumulatedMemblocksSize) - int64(old.AccumulatedMemblocksSize),
+		SampleCacheSize:          int64(snap.SampleCacheSize) - int64(old.SampleCacheSize),
+	}
+}
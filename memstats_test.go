@@ -0,0 +1,38 @@
+package pulseaudio_test
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"testing"
+	"time"
+)
+
+func TestMemstatsDelta(t *testing.T) {
+	now := time.Now()
+	old := pulseaudio.MemstatsSnapshot{
+		Time:                     now,
+		CurrentMemblocks:         10,
+		CurrentMemblocksSize:     4096,
+		AccumulatedMemblocks:     100,
+		AccumulatedMemblocksSize: 40960,
+		SampleCacheSize:          2048,
+	}
+	snap := pulseaudio.MemstatsSnapshot{
+		Time:                     now.Add(time.Minute),
+		CurrentMemblocks:         8,
+		CurrentMemblocksSize:     5120,
+		AccumulatedMemblocks:     150,
+		AccumulatedMemblocksSize: 61440,
+		SampleCacheSize:          2048,
+	}
+	want := pulseaudio.MemstatsDelta{
+		Elapsed:                  time.Minute,
+		CurrentMemblocks:         -2,
+		CurrentMemblocksSize:     1024,
+		AccumulatedMemblocks:     50,
+		AccumulatedMemblocksSize: 20480,
+	}
+	if got := snap.Delta(old); got != want {
+		t.Errorf("delta: got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,122 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"sync"
+)
+
+// Dispatcher forwards a signal event to the registered clients.
+//
+type Dispatcher func(*dbus.Signal)
+
+// Middleware wraps the signal dispatch, to observe, filter, transform or
+// record events. It returns a Dispatcher that must call next to continue the
+// dispatch.
+//
+//   pulse.Use(func(next pulseaudio.Dispatcher) pulseaudio.Dispatcher {
+//   	return func(s *dbus.Signal) {
+//   		start := time.Now()
+//   		next(s)
+//   		log.Println(s.Name, "dispatched in", time.Since(start))
+//   	}
+//   })
+//
+type Middleware func(next Dispatcher) Dispatcher
+
+// Use adds middlewares around the signal dispatch. The first middleware added
+// is the first to receive the signals.
+//
+// Use must be called before Listen.
+//
+func (pulse *Client) Use(middlewares ...Middleware) {
+	pulse.middlewares = append(pulse.middlewares, middlewares...)
+
+	dispatcher := Dispatcher(pulse.dispatch)
+	for i := len(pulse.middlewares) - 1; i >= 0; i-- {
+		dispatcher = pulse.middlewares[i](dispatcher)
+	}
+	pulse.dispatcher = dispatcher
+}
+
+//
+//----------------------------------------------------[ BUILT-IN MIDDLEWARES ]--
+
+// SignalLogger returns a middleware logging all signals with the given printf
+// like function (ex: log.Printf).
+//
+func SignalLogger(logf func(format string, args ...interface{})) Middleware {
+	return func(next Dispatcher) Dispatcher {
+		return func(s *dbus.Signal) {
+			logf("signal %s %s %v", s.Name, s.Path, s.Body)
+			next(s)
+		}
+	}
+}
+
+// PathFilter returns a middleware dropping the signals emitted by objects
+// whose path isn't accepted by keep.
+//
+// Core events like NewSink are emitted by the core object (DbusPath).
+//
+//   pulse.Use(pulseaudio.PathFilter(func(path dbus.ObjectPath) bool {
+//   	return path == pulseaudio.DbusPath || path == mySink
+//   }))
+//
+func PathFilter(keep func(dbus.ObjectPath) bool) Middleware {
+	return func(next Dispatcher) Dispatcher {
+		return func(s *dbus.Signal) {
+			if keep(s.Path) {
+				next(s)
+			}
+		}
+	}
+}
+
+// SignalCounter counts the signals received, by signal name.
+//
+//   counter := pulseaudio.NewSignalCounter()
+//   pulse.Use(counter.Middleware)
+//
+type SignalCounter struct {
+	counts map[string]uint64
+	mu     sync.Mutex
+}
+
+// NewSignalCounter creates a signal counter.
+//
+func NewSignalCounter() *SignalCounter {
+	return &SignalCounter{counts: make(map[string]uint64)}
+}
+
+// Middleware counts the signals, to use with Client.Use.
+//
+func (sc *SignalCounter) Middleware(next Dispatcher) Dispatcher {
+	return func(s *dbus.Signal) {
+		sc.mu.Lock()
+		sc.counts[s.Name]++
+		sc.mu.Unlock()
+		next(s)
+	}
+}
+
+// Count returns the number of signals received with the given name (full Dbus
+// name, like org.PulseAudio.Core1.NewSink).
+//
+func (sc *SignalCounter) Count(name string) uint64 {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.counts[name]
+}
+
+// Counts returns the number of signals received, by signal name.
+//
+func (sc *SignalCounter) Counts() map[string]uint64 {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	counts := make(map[string]uint64, len(sc.counts))
+	for name, count := range sc.counts {
+		counts[name] = count
+	}
+	return counts
+}
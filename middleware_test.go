@@ -0,0 +1,96 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// dispatchRecorder records the paths of the signals it receives.
+type dispatchRecorder struct{ paths []dbus.ObjectPath }
+
+func (rec *dispatchRecorder) DeviceVolumeUpdated(path dbus.ObjectPath, volume []uint32) {
+	rec.paths = append(rec.paths, path)
+}
+
+func (rec *dispatchRecorder) NewSinkPath(path dbus.ObjectPath) { rec.paths = append(rec.paths, path) }
+
+func TestMiddlewares(t *testing.T) {
+	var logs []string
+	logf := func(format string, args ...interface{}) { logs = append(logs, fmt.Sprintf(format, args...)) }
+
+	pulse := pulseaudio.NewReplayClient()
+	rec := &dispatchRecorder{}
+	if errs := pulse.Register(rec); len(errs) > 0 {
+		t.Fatal("register:", errs)
+	}
+
+	// The counter is added first, it must see the signals dropped by the filter.
+	counter := pulseaudio.NewSignalCounter()
+	filter := pulseaudio.PathFilter(func(path dbus.ObjectPath) bool { return path != "/sink1" })
+	pulse.Use(counter.Middleware, pulseaudio.SignalLogger(logf))
+	pulse.Use(filter)
+
+	volume := pulseaudio.DbusInterface + ".Device.VolumeUpdated"
+	pulse.DispatchSignal(&dbus.Signal{Name: volume, Path: "/sink0", Body: []interface{}{[]uint32{1}}})
+	pulse.DispatchSignal(&dbus.Signal{Name: volume, Path: "/sink1", Body: []interface{}{[]uint32{2}}})
+	pulse.DispatchSignal(&dbus.Signal{Name: pulseaudio.DbusInterface + ".NewSink", Path: pulseaudio.DbusPath, Body: []interface{}{dbus.ObjectPath("/sink2")}})
+
+	if want := []dbus.ObjectPath{"/sink0", "/sink2"}; !reflect.DeepEqual(rec.paths, want) {
+		t.Errorf("dispatched: got %v, want %v", rec.paths, want)
+	}
+	if len(logs) != 3 || logs[0] != "signal "+volume+" /sink0 [[1]]" {
+		t.Errorf("logs: got %q", logs)
+	}
+	want := map[string]uint64{volume: 2, pulseaudio.DbusInterface + ".NewSink": 1}
+	if got := counter.Counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("counts: got %v, want %v", got, want)
+	}
+	if got := counter.Count(volume); got != 2 {
+		t.Errorf("count %s: got %d, want 2", volume, got)
+	}
+}
+
+type volumeTracer struct{ trace *[]string }
+
+func (tr volumeTracer) DeviceVolumeUpdated(path dbus.ObjectPath, volume []uint32) {
+	*tr.trace = append(*tr.trace, "object "+string(path))
+}
+
+func TestClientUse(t *testing.T) {
+	var trace []string
+	tracer := func(name string) pulseaudio.Middleware {
+		return func(next pulseaudio.Dispatcher) pulseaudio.Dispatcher {
+			return func(s *dbus.Signal) {
+				trace = append(trace, name+" in")
+				next(s)
+				trace = append(trace, name+" out")
+			}
+		}
+	}
+
+	pulse := pulseaudio.NewReplayClient()
+	if errs := pulse.Register(volumeTracer{&trace}); len(errs) > 0 {
+		t.Fatal("register:", errs)
+	}
+	pulse.Use(tracer("first"))
+	pulse.Use(tracer("second"), pulseaudio.PathFilter(func(path dbus.ObjectPath) bool { return path != "/sink1" }), tracer("third"))
+
+	volume := pulseaudio.DbusInterface + ".Device.VolumeUpdated"
+	pulse.DispatchSignal(&dbus.Signal{Name: volume, Path: "/sink0", Body: []interface{}{[]uint32{1}}})
+	want := []string{"first in", "second in", "third in", "object /sink0", "third out", "second out", "first out"}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("dispatch: got %q, want %q", trace, want)
+	}
+
+	trace = nil
+	pulse.DispatchSignal(&dbus.Signal{Name: volume, Path: "/sink1", Body: []interface{}{[]uint32{2}}})
+	want = []string{"first in", "second in", "second out", "first out"}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("filtered dispatch: got %q, want %q", trace, want)
+	}
+}
@@ -0,0 +1,72 @@
+package pulseaudio
+
+import "github.com/godbus/dbus"
+
+// Module controls a pulseaudio module.
+//
+// Methods list:
+//   Unload      Unloads the module.
+//
+// Properties list:
+//   Uint32
+//     Index           The module index.
+//     !UsageCounter   The number of entities using the module. Not all modules
+//                     support usage counting; in those cases this property does not exist.
+//
+//   String
+//     Name            The module name.
+//
+//   MapString
+//     Arguments       The arguments the module was loaded with.
+//     !PropertyList   The module's property list.
+//
+func (pulse *Client) Module(path dbus.ObjectPath) *Module {
+	return &Module{pulse.newObject(DbusInterface+".Module", path)}
+}
+
+// LoadModule loads a module in the server using the Dbus module loader.
+//
+//   mod, e := pulse.LoadModule("module-null-sink", map[string]string{"sink_name": "test"})
+//
+func (pulse *Client) LoadModule(name string, args map[string]string) (*Module, error) {
+	if args == nil {
+		args = map[string]string{}
+	}
+	var path dbus.ObjectPath
+	e := pulse.Core().Call(DbusInterface+".LoadModule", 0, name, args).Store(&path)
+	if e != nil {
+		return nil, e
+	}
+	return pulse.Module(path), nil
+}
+
+// Module is a typed access to a pulseaudio module.
+// See Client.Module for the properties list.
+//
+type Module struct {
+	*Object
+}
+
+// Index returns the module index.
+//
+func (mod *Module) Index() (uint32, error) {
+	return mod.Uint32("Index")
+}
+
+// Name returns the module name.
+//
+func (mod *Module) Name() (string, error) {
+	return mod.String("Name")
+}
+
+// Arguments returns the arguments the module was loaded with.
+//
+func (mod *Module) Arguments() (map[string]string, error) {
+	return mod.MapString("Arguments")
+}
+
+// Unload unloads the module.
+//
+func (mod *Module) Unload() error {
+	return mod.Call(mod.prefix+".Unload", 0).Err
+}
@@ -0,0 +1,155 @@
+package pulseaudio
+
+import "strconv"
+
+// Well-known property list keys.
+// See pulseaudio proplist.h for the complete list and values descriptions.
+//
+const (
+	PropMediaName             = "media.name"
+	PropMediaTitle            = "media.title"
+	PropMediaRole             = "media.role"
+	PropEventID               = "event.id"
+	PropApplicationName       = "application.name"
+	PropApplicationID         = "application.id"
+	PropApplicationVersion    = "application.version"
+	PropApplicationIconName   = "application.icon_name"
+	PropApplicationProcessID  = "application.process.id"
+	PropApplicationProcessBin = "application.process.binary"
+	PropDeviceString          = "device.string"
+	PropDeviceAPI             = "device.api"
+	PropDeviceDescription     = "device.description"
+	PropDeviceBus             = "device.bus"
+	PropDeviceClass           = "device.class"
+	PropDeviceFormFactor      = "device.form_factor"
+	PropDeviceIconName        = "device.icon_name"
+	PropDeviceProfileName     = "device.profile.name"
+)
+
+// UpdateMode defines how a property list update is merged with the current one.
+//
+type UpdateMode uint32
+
+// Property list update modes.
+//
+const (
+	UpdateSet     UpdateMode = iota // Replace the entire property list with the new one.
+	UpdateMerge                     // Add new keys, but keep the existing values of current keys.
+	UpdateReplace                   // Add new keys and replace the values of current keys.
+)
+
+// PropertyList is a pulseaudio property list, as returned by the PropertyList
+// property of most objects.
+//
+// Values are kept as raw bytes as some properties may hold binary data.
+// Text values are \0 terminated, use the String method to get them.
+//
+type PropertyList map[string][]byte
+
+// String returns the text value of a property, without its \0 terminator.
+// An empty string is returned if the key is missing.
+//
+func (pl PropertyList) String(key string) string {
+	val, _ := pl.Lookup(key)
+	return val
+}
+
+// Lookup returns the text value of a property, without its \0 terminator, and
+// whether the key was found.
+//
+func (pl PropertyList) Lookup(key string) (string, bool) {
+	val, ok := pl[key]
+	return trimNul(val), ok
+}
+
+// SetString sets the text value of a property, adding the \0 terminator.
+//
+func (pl PropertyList) SetString(key, val string) {
+	pl[key] = append([]byte(val), 0)
+}
+
+// Strings returns the property list with all values converted to text.
+//
+func (pl PropertyList) Strings() map[string]string {
+	return propertiesToStrings(pl)
+}
+
+// MediaName returns the media.name property.
+//
+func (pl PropertyList) MediaName() string { return pl.String(PropMediaName) }
+
+// MediaRole returns the media.role property (video, music, game, event, phone...).
+//
+func (pl PropertyList) MediaRole() string { return pl.String(PropMediaRole) }
+
+// ApplicationName returns the application.name property.
+//
+func (pl PropertyList) ApplicationName() string { return pl.String(PropApplicationName) }
+
+// ApplicationID returns the application.id property.
+//
+func (pl PropertyList) ApplicationID() string { return pl.String(PropApplicationID) }
+
+// ApplicationIconName returns the application.icon_name property.
+//
+func (pl PropertyList) ApplicationIconName() string { return pl.String(PropApplicationIconName) }
+
+// ApplicationProcessID returns the application.process.id property.
+//
+func (pl PropertyList) ApplicationProcessID() (int, error) {
+	return strconv.Atoi(pl.String(PropApplicationProcessID))
+}
+
+// DeviceDescription returns the device.description property.
+//
+func (pl PropertyList) DeviceDescription() string { return pl.String(PropDeviceDescription) }
+
+// DeviceIconName returns the device.icon_name property.
+//
+func (pl PropertyList) DeviceIconName() string { return pl.String(PropDeviceIconName) }
+
+// DeviceBus returns the device.bus property (usb, pci, bluetooth...).
+//
+func (pl PropertyList) DeviceBus() string { return pl.String(PropDeviceBus) }
+
+// DeviceClass returns the device.class property (sound, modem, monitor, filter...).
+//
+func (pl PropertyList) DeviceClass() string { return pl.String(PropDeviceClass) }
+
+// DeviceFormFactor returns the device.form_factor property (internal, speaker,
+// handset, tv, webcam, microphone, headset, headphone, hands-free, car, hifi,
+// computer, portable).
+//
+func (pl PropertyList) DeviceFormFactor() string { return pl.String(PropDeviceFormFactor) }
+
+//
+//-------------------------------------------------------[ OBJECT PROPERTIES ]--
+
+// PropertyList queries an object property and return it as PropertyList.
+//
+func (dev *Object) PropertyList(name string) (val PropertyList, e error) {
+	e = dev.Get(name, &val)
+	return val, e
+}
+
+// UpdateProperties updates the property list of a client object.
+//
+func (dev *Object) UpdateProperties(props PropertyList, mode UpdateMode) error {
+	return dev.Call(dev.prefix+".UpdateProperties", 0, props, mode).Err
+}
+
+// RemoveProperties removes the given keys from the property list of a client
+// object. Missing keys are ignored.
+//
+func (dev *Object) RemoveProperties(keys ...string) error {
+	return dev.Call(dev.prefix+".RemoveProperties", 0, keys).Err
+}
+
+// trimNul removes the \0 terminator of a property value if any.
+//
+func trimNul(val []byte) string {
+	if len(val) > 0 && val[len(val)-1] == 0 {
+		val = val[:len(val)-1]
+	}
+	return string(val)
+}
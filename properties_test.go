@@ -0,0 +1,55 @@
+package pulseaudio_test
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"reflect"
+	"testing"
+)
+
+func TestPropertyList(t *testing.T) {
+	props := pulseaudio.PropertyList{
+		pulseaudio.PropApplicationName:      []byte("player\x00"),
+		pulseaudio.PropApplicationProcessID: []byte("1234\x00"),
+		pulseaudio.PropMediaRole:            []byte("music"), // no terminator.
+		"binary":                            {0, 1, 0},
+		"empty":                             {},
+	}
+
+	for key, want := range map[string]string{
+		pulseaudio.PropApplicationName: "player",
+		pulseaudio.PropMediaRole:       "music",
+		"binary":                       "\x00\x01",
+		"empty":                        "",
+		"missing":                      "",
+	} {
+		if got := props.String(key); got != want {
+			t.Errorf("String(%q): got %q, want %q", key, got, want)
+		}
+	}
+
+	if _, ok := props.Lookup("empty"); !ok {
+		t.Error("Lookup(empty): key not found")
+	}
+	if _, ok := props.Lookup("missing"); ok {
+		t.Error("Lookup(missing): key found")
+	}
+
+	if props.ApplicationName() != "player" || props.MediaRole() != "music" {
+		t.Errorf("helpers: got %q %q", props.ApplicationName(), props.MediaRole())
+	}
+	pid, e := props.ApplicationProcessID()
+	if e != nil || pid != 1234 {
+		t.Errorf("ApplicationProcessID: got %d, %v", pid, e)
+	}
+
+	props.SetString(pulseaudio.PropDeviceIconName, "audio-card")
+	if !reflect.DeepEqual(props[pulseaudio.PropDeviceIconName], []byte("audio-card\x00")) {
+		t.Errorf("SetString: got %q", props[pulseaudio.PropDeviceIconName])
+	}
+
+	strs := props.Strings()
+	if len(strs) != len(props) || strs[pulseaudio.PropDeviceIconName] != "audio-card" {
+		t.Errorf("Strings: got %q", strs)
+	}
+}
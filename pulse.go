@@ -3,10 +3,13 @@ package pulseaudio
 import (
 	"github.com/godbus/dbus"
 
+	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 // Dbus objects paths.
@@ -22,6 +25,12 @@ type Client struct {
 	hooker        *Hooker
 	ch            chan *dbus.Signal
 	unknownSignal func(*dbus.Signal)
+	dispatcher    Dispatcher // Middlewares chain, nil without middleware.
+	middlewares   []Middleware
+	replay        ReplayHandler // Answers the objects calls without connection.
+
+	rampMu sync.Mutex
+	ramps  map[dbus.ObjectPath]chan struct{} // Cancels the volume ramp in progress, by object.
 }
 
 // New creates a new pulseaudio Dbus client session.
@@ -43,21 +52,38 @@ func New() (*Client, error) { // chan *dbus.Signal
 		return nil, e
 	}
 
+	return newClient(conn), nil
+}
+
+// NewReplayClient creates a pulseaudio client without server connection, to
+// dispatch recorded signals (see Replay). Objects can be registered, but Dbus
+// methods and properties return ErrNoServer, unless a replay handler is set.
+//
+func NewReplayClient() *Client {
+	return newClient(nil)
+}
+
+func newClient(conn *dbus.Conn) *Client {
 	pulse := &Client{
 		conn:          conn,
 		hooker:        NewHooker(),
 		unknownSignal: func(s *dbus.Signal) { fmt.Println("unknown signal", s.Name, s.Path) },
+		ramps:         make(map[dbus.ObjectPath]chan struct{}),
 	}
 
-	pulse.hooker.AddCalls(PulseCalls)
-	pulse.hooker.AddTypes(PulseTypes)
+	pulse.hooker.AddEvents(PulseEvents)
+	pulse.hooker.AddEvents(StreamRestoreEvents)
+	pulse.hooker.AddEvents(EqualizerEvents)
 
-	return pulse, nil
+	return pulse
 }
 
 // Close closes the DBus connection. The client can't be reused after.
 //
 func (pulse *Client) Close() error {
+	if pulse.conn == nil {
+		return nil
+	}
 	return pulse.conn.Close()
 }
 
@@ -67,6 +93,9 @@ func (pulse *Client) Close() error {
 //
 func (pulse *Client) Register(obj interface{}) (errs []error) {
 	tolisten := pulse.hooker.Register(obj)
+	if pulse.conn == nil {
+		return nil // Replay client.
+	}
 	for _, name := range tolisten {
 		e := pulse.ListenForSignal(name)
 		if e != nil {
@@ -76,10 +105,14 @@ func (pulse *Client) Register(obj interface{}) (errs []error) {
 	return errs
 }
 
-// Unregister disconnects an object from the pulseaudio events hooks.
+// Unregister disconnects an object, or a function Handle, from the pulseaudio
+// events hooks.
 //
 func (pulse *Client) Unregister(obj interface{}) (errs []error) {
 	tounlisten := pulse.hooker.Unregister(obj)
+	if pulse.conn == nil {
+		return nil // Replay client.
+	}
 	for _, name := range tounlisten {
 		e := pulse.StopListeningForSignal(name)
 		if e != nil {
@@ -107,14 +140,25 @@ func (pulse *Client) StopListening() {
 	close(pulse.ch)
 }
 
-// DispatchSignal forwards a signal event to the registered clients.
+// DispatchSignal forwards a signal event to the registered clients, through
+// the middlewares set with Use.
 //
 func (pulse *Client) DispatchSignal(s *dbus.Signal) {
+	if pulse.dispatcher != nil {
+		pulse.dispatcher(s)
+		return
+	}
+	pulse.dispatch(s)
+}
+
+// dispatch forwards a signal event to the registered clients.
+//
+func (pulse *Client) dispatch(s *dbus.Signal) {
+	// Core signals are referenced without the interface prefix, extensions
+	// signals with their full name.
 	name := strings.TrimPrefix(string(s.Name), DbusInterface+".")
-	if name != s.Name { // dbus interface matched.
-		if pulse.hooker.Call(name, s) {
-			return // signal was defined (even if no clients are connected).
-		}
+	if pulse.hooker.Call(name, s) {
+		return // signal was defined (even if no clients are connected).
 	}
 	pulse.unknownSignal(s)
 }
@@ -129,113 +173,49 @@ func (pulse *Client) SetOnUnknownSignal(call func(s *dbus.Signal)) {
 //------------------------------------------------------------[ DBUS METHODS ]--
 
 // ListenForSignal registers a new event to listen.
+// Core signals can be given without the interface prefix.
 //
 func (pulse *Client) ListenForSignal(name string, paths ...dbus.ObjectPath) error {
-	args := []interface{}{DbusInterface + "." + name, paths}
+	args := []interface{}{signalName(name), paths}
 	return pulse.Core().Call("ListenForSignal", 0, args...).Err
 }
 
 // StopListeningForSignal unregisters an listened event.
 //
 func (pulse *Client) StopListeningForSignal(name string) error {
-	return pulse.Core().Call("StopListeningForSignal", 0, DbusInterface+"."+name).Err
+	return pulse.Core().Call("StopListeningForSignal", 0, signalName(name)).Err
 }
 
+// signalName returns the full Dbus name of a signal.
+// Core signals are prefixed with the core interface, extensions signals must
+// already be qualified (org.PulseAudio.Ext...).
 //
-//-----------------------------------------------------[ CALLBACK INTERFACES ]--
-
-// OnFallbackSinkUpdated is an interface to the FallbackSinkUpdated method.
-type OnFallbackSinkUpdated interface {
-	FallbackSinkUpdated(dbus.ObjectPath)
-}
-
-// OnFallbackSinkUnset is an interface to the FallbackSinkUnset method.
-type OnFallbackSinkUnset interface {
-	FallbackSinkUnset()
-}
-
-// OnNewSink is an interface to the NewSink method.
-type OnNewSink interface {
-	NewSink(dbus.ObjectPath)
-}
-
-// OnSinkRemoved is an interface to the SinkRemoved method.
-type OnSinkRemoved interface {
-	SinkRemoved(dbus.ObjectPath)
-}
-
-// OnNewPlaybackStream is an interface to the NewPlaybackStream method.
-type OnNewPlaybackStream interface {
-	NewPlaybackStream(dbus.ObjectPath)
-}
-
-// OnPlaybackStreamRemoved is an interface to the PlaybackStreamRemoved method.
-type OnPlaybackStreamRemoved interface {
-	PlaybackStreamRemoved(dbus.ObjectPath)
-}
-
-// OnDeviceVolumeUpdated is an interface to the DeviceVolumeUpdated method.
-type OnDeviceVolumeUpdated interface {
-	DeviceVolumeUpdated(dbus.ObjectPath, []uint32)
-}
-
-// OnDeviceMuteUpdated is an interface to the DeviceMuteUpdated method.
-type OnDeviceMuteUpdated interface {
-	DeviceMuteUpdated(dbus.ObjectPath, bool)
-}
-
-// OnStreamVolumeUpdated is an interface to the StreamVolumeUpdated method.
-type OnStreamVolumeUpdated interface {
-	StreamVolumeUpdated(dbus.ObjectPath, []uint32)
-}
-
-// OnStreamMuteUpdated is an interface to the StreamMuteUpdated method.
-type OnStreamMuteUpdated interface {
-	StreamMuteUpdated(dbus.ObjectPath, bool)
-}
-
-// OnDeviceActivePortUpdated is an interface to the DeviceActivePortUpdated method.
-type OnDeviceActivePortUpdated interface {
-	DeviceActivePortUpdated(dbus.ObjectPath, dbus.ObjectPath)
+func signalName(name string) string {
+	if strings.HasPrefix(name, "org.") {
+		return name
+	}
+	return DbusInterface + "." + name
 }
 
 //
 //--------------------------------------------------------[ CALLBACK METHODS ]--
 
-// PulseCalls defines callbacks methods to call the matching object method with
-// type-asserted arguments.
-// Public so it can be hacked before the first Register.
-//
-var PulseCalls = Calls{
-	"FallbackSinkUpdated":      func(m Msg) { m.O.(OnFallbackSinkUpdated).FallbackSinkUpdated(m.P) },
-	"FallbackSinkUnset":        func(m Msg) { m.O.(OnFallbackSinkUnset).FallbackSinkUnset() },
-	"NewSink":                  func(m Msg) { m.O.(OnNewSink).NewSink(m.P) },
-	"SinkRemoved":              func(m Msg) { m.O.(OnSinkRemoved).SinkRemoved(m.P) },
-	"NewPlaybackStream":        func(m Msg) { m.O.(OnNewPlaybackStream).NewPlaybackStream(m.D[0].(dbus.ObjectPath)) },
-	"PlaybackStreamRemoved":    func(m Msg) { m.O.(OnPlaybackStreamRemoved).PlaybackStreamRemoved(m.D[0].(dbus.ObjectPath)) },
-	"Device.VolumeUpdated":     func(m Msg) { m.O.(OnDeviceVolumeUpdated).DeviceVolumeUpdated(m.P, m.D[0].([]uint32)) },
-	"Device.MuteUpdated":       func(m Msg) { m.O.(OnDeviceMuteUpdated).DeviceMuteUpdated(m.P, m.D[0].(bool)) },
-	"Device.ActivePortUpdated": func(m Msg) { m.O.(OnDeviceActivePortUpdated).DeviceActivePortUpdated(m.P, m.D[0].(dbus.ObjectPath)) },
-	"Stream.VolumeUpdated":     func(m Msg) { m.O.(OnStreamVolumeUpdated).StreamVolumeUpdated(m.P, m.D[0].([]uint32)) },
-	"Stream.MuteUpdated":       func(m Msg) { m.O.(OnStreamMuteUpdated).StreamMuteUpdated(m.P, m.D[0].(bool)) },
-}
-
-// PulseTypes defines interface types for events to register.
-// Public so it can be hacked before the first Register.
-//
-var PulseTypes = map[string]reflect.Type{
-	"FallbackSinkUpdated":      reflect.TypeOf((*OnFallbackSinkUpdated)(nil)).Elem(),
-	"FallbackSinkUnset":        reflect.TypeOf((*OnFallbackSinkUnset)(nil)).Elem(),
-	"NewSink":                  reflect.TypeOf((*OnNewSink)(nil)).Elem(),
-	"SinkRemoved":              reflect.TypeOf((*OnSinkRemoved)(nil)).Elem(),
-	"NewPlaybackStream":        reflect.TypeOf((*OnNewPlaybackStream)(nil)).Elem(),
-	"PlaybackStreamRemoved":    reflect.TypeOf((*OnPlaybackStreamRemoved)(nil)).Elem(),
-	"Device.VolumeUpdated":     reflect.TypeOf((*OnDeviceVolumeUpdated)(nil)).Elem(),
-	"Device.MuteUpdated":       reflect.TypeOf((*OnDeviceMuteUpdated)(nil)).Elem(),
-	"Device.ActivePortUpdated": reflect.TypeOf((*OnDeviceActivePortUpdated)(nil)).Elem(),
-	"Stream.VolumeUpdated":     reflect.TypeOf((*OnStreamVolumeUpdated)(nil)).Elem(),
-	"Stream.MuteUpdated":       reflect.TypeOf((*OnStreamMuteUpdated)(nil)).Elem(),
-}
+// PulseCalls defines callbacks methods to call the matching object method
+// with type-checked arguments. Derived from PulseEvents when the package is
+// loaded.
+//
+// Deprecated: changes are ignored by new clients. Edit PulseEvents before the
+// client creation, or use Client.AddEvents.
+//
+var PulseCalls = PulseEvents.Calls()
+
+// PulseTypes defines interface types for events to register. Derived from
+// PulseEvents when the package is loaded.
+//
+// Deprecated: changes are ignored by new clients. Edit PulseEvents before the
+// client creation, or use Client.AddEvents.
+//
+var PulseTypes = PulseEvents.Types()
 
 //
 //------------------------------------------------------------------[ COMMON ]--
@@ -274,36 +254,107 @@ type Object struct {
 // NewObject creates a dbus Object with properties access methods.
 //
 func NewObject(conn *dbus.Conn, interf string, path dbus.ObjectPath) *Object {
+	if conn == nil {
+		return &Object{offlineObject{path: path}, interf}
+	}
 	return &Object{conn.Object(interf, path), interf}
 }
 
+// newObject creates a dbus Object of the client. Objects of a replay client
+// forward their calls to the replay handler.
+//
+func (pulse *Client) newObject(interf string, path dbus.ObjectPath) *Object {
+	if pulse.conn == nil {
+		return &Object{offlineObject{path: path, pulse: pulse}, interf}
+	}
+	return pulse.newObject(interf, path)
+}
+
+// ErrNoServer is returned by the objects of a client without server, see
+// NewReplayClient.
+var ErrNoServer = errors.New("dbus: no server connection")
+
+// ReplayHandler answers the Dbus calls of the objects of a replay client, to
+// simulate a server in tests. method is the full method name, properties are
+// queried with org.freedesktop.DBus.Properties.Get and Set. It returns the
+// reply body.
+//
+type ReplayHandler func(path dbus.ObjectPath, method string, args ...interface{}) ([]interface{}, error)
+
+// SetReplayHandler sets the handler answering the objects calls of a replay
+// client. Without handler, calls fail with ErrNoServer. It must be set before
+// the client is used.
+//
+func (pulse *Client) SetReplayHandler(handler ReplayHandler) {
+	pulse.replay = handler
+}
+
+// offlineObject is a Dbus object without connection. Calls are forwarded to
+// the replay handler of the client, or fail with ErrNoServer.
+//
+type offlineObject struct {
+	path  dbus.ObjectPath
+	pulse *Client // Nil for objects created without client.
+}
+
+func (obj offlineObject) Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	return obj.Go(method, flags, nil, args...)
+}
+
+func (obj offlineObject) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	return obj.Go(method, flags, nil, args...)
+}
+
+func (obj offlineObject) Go(method string, flags dbus.Flags, ch chan *dbus.Call, args ...interface{}) *dbus.Call {
+	if ch == nil {
+		ch = make(chan *dbus.Call, 1)
+	}
+	call := &dbus.Call{Path: obj.path, Method: method, Args: args, Done: ch, Err: ErrNoServer}
+	if obj.pulse != nil && obj.pulse.replay != nil {
+		call.Body, call.Err = obj.pulse.replay(obj.path, method, args...)
+	}
+	select {
+	case ch <- call:
+	default:
+	}
+	return call
+}
+
+func (obj offlineObject) GoWithContext(ctx context.Context, method string, flags dbus.Flags, ch chan *dbus.Call, args ...interface{}) *dbus.Call {
+	return obj.Go(method, flags, ch, args...)
+}
+
+func (obj offlineObject) AddMatchSignal(iface, member string, options ...dbus.MatchOption) *dbus.Call {
+	return obj.Go(iface+"."+member, 0, nil)
+}
+
+func (obj offlineObject) RemoveMatchSignal(iface, member string, options ...dbus.MatchOption) *dbus.Call {
+	return obj.Go(iface+"."+member, 0, nil)
+}
+
+func (obj offlineObject) GetProperty(p string) (v dbus.Variant, e error) {
+	idx := strings.LastIndex(p, ".")
+	if idx == -1 || idx+1 == len(p) {
+		return v, fmt.Errorf("dbus: invalid property %s", p)
+	}
+	e = obj.Call("org.freedesktop.DBus.Properties.Get", 0, p[:idx], p[idx+1:]).Store(&v)
+	return v, e
+}
+
+func (obj offlineObject) Destination() string { return "" }
+
+func (obj offlineObject) Path() dbus.ObjectPath { return obj.path }
+
 // Get queries an object property and set its value to dest.
-// dest must be a pointer to the type of data returned by the method.
+// dest must be a pointer to a type compatible with the data returned by the
+// method. See StoreValue for the conversions applied.
 //
 func (dev *Object) Get(property string, dest interface{}) error {
 	v, e := dev.GetProperty(dev.prefix + "." + property)
 	if e != nil {
 		return e
 	}
-
-	switch val := v.Value().(type) {
-	case bool, uint32, uint64, string, dbus.ObjectPath,
-		[]uint32, []string, []dbus.ObjectPath:
-
-		reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(val))
-		return nil
-
-	case map[string][]byte:
-		tmp := make(map[string]string)
-		for k, v := range val {
-			if len(v) > 0 {
-				tmp[k] = string(v[:len(v)-1]) // remove \0 at end.
-			}
-		}
-		reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(tmp))
-		return nil
-	}
-	return fmt.Errorf("unknown type, %T to %T", v.Value(), dest)
+	return StoreValue(v.Value(), dest)
 }
 
 // Set updates the given object property with value.
@@ -336,6 +387,13 @@ func (dev *Object) Uint64(name string) (val uint64, e error) {
 	return val, e
 }
 
+// Float64 queries an object property and return it as float64.
+//
+func (dev *Object) Float64(name string) (val float64, e error) {
+	e = dev.Get(name, &val)
+	return val, e
+}
+
 // String queries an object property and return it as string.
 //
 func (dev *Object) String(name string) (val string, e error) {
@@ -357,6 +415,13 @@ func (dev *Object) ListUint32(name string) (val []uint32, e error) {
 	return val, e
 }
 
+// ListFloat64 queries an object property and return it as []float64.
+//
+func (dev *Object) ListFloat64(name string) (val []float64, e error) {
+	e = dev.Get(name, &val)
+	return val, e
+}
+
 // ListString queries an object property and return it as []string.
 //
 func (dev *Object) ListString(name string) (val []string, e error) {
@@ -418,8 +483,7 @@ type Types map[string]reflect.Type
 // An object can be referenced multiple times.
 // If an object declares all methods, it will be referenced in every field.
 //   hooker:= NewHooker()
-//   hooker.AddCalls(myCalls)
-//   hooker.AddTypes(myTypes)
+//   hooker.AddEvents(myEvents) // or AddCalls(myCalls) and AddTypes(myTypes).
 //
 //   // create a type with some of your callback methods and register it.
 //   tolisten := hooker.Register(obj) // tolisten is the list of events you may have to listen.
@@ -428,9 +492,12 @@ type Types map[string]reflect.Type
 //   matched := Call(signalName, dbusSignal)
 //
 type Hooker struct {
-	Hooks map[string][]interface{}
-	Calls Calls
-	Types Types
+	Hooks  map[string][]interface{}
+	Calls  Calls
+	Types  Types
+	Events map[string][]EventDef
+
+	coalesced map[interface{}]*coalescer // Objects with coalesced events.
 }
 
 // NewHooker handles a loosely coupled hook interface to forward dbus signals
@@ -438,22 +505,35 @@ type Hooker struct {
 //
 func NewHooker() *Hooker {
 	return &Hooker{
-		Hooks: make(map[string][]interface{}),
-		Calls: make(Calls),
-		Types: make(Types),
+		Hooks:  make(map[string][]interface{}),
+		Calls:  make(Calls),
+		Types:  make(Types),
+		Events: make(map[string][]EventDef),
+
+		coalesced: make(map[interface{}]*coalescer),
 	}
 }
 
 // Call forwards a Dbus event to registered clients for this event.
 //
 func (hook Hooker) Call(name string, s *dbus.Signal) bool {
-	call, ok := hook.Calls[name]
-	if !ok { // Signal name not defined.
+	if _, ok := hook.Calls[name]; !ok { // Signal name not defined.
 		return false
 	}
+	if path, ok := removedPath(name, s); ok { // Drop the coalesced events of the object.
+		for _, c := range hook.coalesced {
+			c.drop(path)
+		}
+	}
 	if list, ok := hook.Hooks[name]; ok { // Hook clients found.
 		for _, obj := range list {
-			call(Msg{obj, s.Path, s.Body})
+			call := hook.callFor(name, obj)
+			msg := Msg{obj, s.Path, s.Body}
+			if c, ok := hook.coalescerOf(obj); ok && c.names[name] {
+				c.push(name, call, msg)
+				continue
+			}
+			call(msg)
 		}
 	}
 	return true
@@ -466,7 +546,11 @@ func (hook Hooker) Call(name string, s *dbus.Signal) bool {
 func (hook Hooker) Register(obj interface{}) (tolisten []string) {
 	t := reflect.ValueOf(obj).Type()
 	for name, modelType := range hook.Types {
-		if t.Implements(modelType) {
+		found, implements := hook.bind(name, obj)
+		if !found {
+			implements = t.Implements(modelType)
+		}
+		if implements {
 			hook.Hooks[name] = append(hook.Hooks[name], obj)
 			if len(hook.Hooks[name]) == 1 { // First client registered for this event. need to listen.
 				tolisten = append(tolisten, name)
@@ -479,6 +563,10 @@ func (hook Hooker) Register(obj interface{}) (tolisten []string) {
 // Unregister disconnects an object from the events hooks.
 //
 func (hook Hooker) Unregister(obj interface{}) (tounlisten []string) {
+	if c, ok := hook.coalescerOf(obj); ok {
+		c.stop()
+		delete(hook.coalesced, obj)
+	}
 	for name, list := range hook.Hooks {
 		hook.Hooks[name] = hook.remove(list, obj)
 		if len(hook.Hooks[name]) == 0 {
@@ -54,6 +54,12 @@ func coverFailPath(t *testing.T) {
 	testFatal(e, "get core extensions")
 	log.Println("CoreExtensions", exts)
 
+	me, e := pulse.MyClient()
+	testFatal(e, "get my client")
+	props, e := me.PropertyList()
+	testFatal(e, "get my client properties")
+	log.Println("MyClient", me.Path(), props.ApplicationName())
+
 	sinks, e := pulse.Core().ListPath("Sinks")
 	testFatal(e, "get list of sinks")
 
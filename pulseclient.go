@@ -0,0 +1,88 @@
+package pulseaudio
+
+import "github.com/godbus/dbus"
+
+// PulseClient is a typed access to a pulseaudio client object, an application
+// connected to the server. See Client for the properties list.
+//
+// The generic Object methods are still available for other properties.
+//
+type PulseClient struct {
+	*Object
+}
+
+// TypedClient returns a typed access to a pulseaudio client object.
+//
+func (pulse *Client) TypedClient(path dbus.ObjectPath) *PulseClient {
+	return &PulseClient{pulse.Client(path)}
+}
+
+// Index returns the client index.
+//
+func (cl *PulseClient) Index() (uint32, error) {
+	return cl.Uint32("Index")
+}
+
+// Driver returns the driver that implements the client object.
+//
+func (cl *PulseClient) Driver() (string, error) {
+	return cl.String("Driver")
+}
+
+// OwnerModule returns the module that owns this client.
+// The property doesn't exist if no module claims ownership.
+//
+func (cl *PulseClient) OwnerModule() (dbus.ObjectPath, error) {
+	return cl.ObjectPath("OwnerModule")
+}
+
+// PlaybackStreams returns the playback streams owned by this client.
+//
+func (cl *PulseClient) PlaybackStreams() ([]dbus.ObjectPath, error) {
+	return cl.ListPath("PlaybackStreams")
+}
+
+// RecordStreams returns the record streams owned by this client.
+//
+func (cl *PulseClient) RecordStreams() ([]dbus.ObjectPath, error) {
+	return cl.ListPath("RecordStreams")
+}
+
+// PropertyList returns the client property list.
+//
+func (cl *PulseClient) PropertyList() (PropertyList, error) {
+	return cl.Object.PropertyList("PropertyList")
+}
+
+// Kill disconnects the client from the server.
+//
+func (cl *PulseClient) Kill() error {
+	return cl.Call(cl.prefix+".Kill", 0).Err
+}
+
+//
+//----------------------------------------------------------[ CLIENT HELPERS ]--
+
+// MyClient returns the pulseaudio client object assigned to our connection.
+//
+func (pulse *Client) MyClient() (*PulseClient, error) {
+	path, e := pulse.Core().ObjectPath("MyClient")
+	if e != nil {
+		return nil, e
+	}
+	return pulse.TypedClient(path), nil
+}
+
+// Clients returns all clients currently connected to the server.
+//
+func (pulse *Client) Clients() ([]*PulseClient, error) {
+	paths, e := pulse.Core().ListPath("Clients")
+	if e != nil {
+		return nil, e
+	}
+	clients := make([]*PulseClient, len(paths))
+	for i, path := range paths {
+		clients[i] = pulse.TypedClient(path)
+	}
+	return clients, nil
+}
@@ -0,0 +1,1265 @@
+// Code generated by pulsegen. DO NOT EDIT.
+
+package pulsedbus
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+)
+
+// Dbus interfaces names.
+const (
+	CardInterface             = "org.PulseAudio.Core1.Card"
+	CardProfileInterface      = "org.PulseAudio.Core1.CardProfile"
+	ClientInterface           = "org.PulseAudio.Core1.Client"
+	CoreInterface             = "org.PulseAudio.Core1"
+	DevicePortInterface       = "org.PulseAudio.Core1.DevicePort"
+	EqualizerInterface        = "org.PulseAudio.Ext.Equalizing1.Equalizer"
+	EqualizerManagerInterface = "org.PulseAudio.Ext.Equalizing1.Manager"
+	LadspaInterface           = "org.PulseAudio.Ext.Ladspa1"
+	MemstatsInterface         = "org.PulseAudio.Core1.Memstats"
+	ModuleInterface           = "org.PulseAudio.Core1.Module"
+	RestoreEntryInterface     = "org.PulseAudio.Ext.StreamRestore1.RestoreEntry"
+	SampleInterface           = "org.PulseAudio.Core1.Sample"
+	DeviceInterface           = "org.PulseAudio.Core1.Device"
+	SinkInterface             = "org.PulseAudio.Core1.Sink"
+	SourceInterface           = "org.PulseAudio.Core1.Source"
+	StreamInterface           = "org.PulseAudio.Core1.Stream"
+	StreamRestoreInterface    = "org.PulseAudio.Ext.StreamRestore1"
+)
+
+//
+//--------------------------------------------------------------------[ CARD ]--
+
+// Card wraps an object with the org.PulseAudio.Core1.Card interface.
+type Card struct{ obj *pulseaudio.Object }
+
+// NewCard returns the Card object at path.
+func NewCard(pulse *pulseaudio.Client, path dbus.ObjectPath) Card {
+	return Card{pulse.Object(CardInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Card) Object() *pulseaudio.Object { return o.obj }
+
+// Index gets the Index property.
+func (o Card) Index() (val uint32, e error) {
+	e = o.obj.Get("Index", &val)
+	return val, e
+}
+
+// Name gets the Name property.
+func (o Card) Name() (val string, e error) {
+	e = o.obj.Get("Name", &val)
+	return val, e
+}
+
+// Driver gets the Driver property.
+func (o Card) Driver() (val string, e error) {
+	e = o.obj.Get("Driver", &val)
+	return val, e
+}
+
+// OwnerModule gets the OwnerModule property.
+func (o Card) OwnerModule() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("OwnerModule", &val)
+	return val, e
+}
+
+// Sinks gets the Sinks property.
+func (o Card) Sinks() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Sinks", &val)
+	return val, e
+}
+
+// Sources gets the Sources property.
+func (o Card) Sources() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Sources", &val)
+	return val, e
+}
+
+// Profiles gets the Profiles property.
+func (o Card) Profiles() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Profiles", &val)
+	return val, e
+}
+
+// ActiveProfile gets the ActiveProfile property.
+func (o Card) ActiveProfile() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("ActiveProfile", &val)
+	return val, e
+}
+
+// SetActiveProfile sets the ActiveProfile property.
+func (o Card) SetActiveProfile(val dbus.ObjectPath) error {
+	return o.obj.Set("ActiveProfile", val)
+}
+
+// PropertyList gets the PropertyList property.
+func (o Card) PropertyList() (val pulseaudio.PropertyList, e error) {
+	e = o.obj.Get("PropertyList", &val)
+	return val, e
+}
+
+// GetProfileByName calls the GetProfileByName method.
+func (o Card) GetProfileByName(name string) (profile dbus.ObjectPath, e error) {
+	e = storeCall(o.obj.Call(CardInterface+".GetProfileByName", 0, name), &profile)
+	return profile, e
+}
+
+//
+//-------------------------------------------------------------[ CARDPROFILE ]--
+
+// CardProfile wraps an object with the org.PulseAudio.Core1.CardProfile interface.
+type CardProfile struct{ obj *pulseaudio.Object }
+
+// NewCardProfile returns the CardProfile object at path.
+func NewCardProfile(pulse *pulseaudio.Client, path dbus.ObjectPath) CardProfile {
+	return CardProfile{pulse.Object(CardProfileInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o CardProfile) Object() *pulseaudio.Object { return o.obj }
+
+// Index gets the Index property.
+func (o CardProfile) Index() (val uint32, e error) {
+	e = o.obj.Get("Index", &val)
+	return val, e
+}
+
+// Name gets the Name property.
+func (o CardProfile) Name() (val string, e error) {
+	e = o.obj.Get("Name", &val)
+	return val, e
+}
+
+// Description gets the Description property.
+func (o CardProfile) Description() (val string, e error) {
+	e = o.obj.Get("Description", &val)
+	return val, e
+}
+
+// Sinks gets the Sinks property.
+func (o CardProfile) Sinks() (val uint32, e error) {
+	e = o.obj.Get("Sinks", &val)
+	return val, e
+}
+
+// Sources gets the Sources property.
+func (o CardProfile) Sources() (val uint32, e error) {
+	e = o.obj.Get("Sources", &val)
+	return val, e
+}
+
+// Priority gets the Priority property.
+func (o CardProfile) Priority() (val uint32, e error) {
+	e = o.obj.Get("Priority", &val)
+	return val, e
+}
+
+// Available gets the Available property.
+func (o CardProfile) Available() (val bool, e error) {
+	e = o.obj.Get("Available", &val)
+	return val, e
+}
+
+//
+//------------------------------------------------------------------[ CLIENT ]--
+
+// Client wraps an object with the org.PulseAudio.Core1.Client interface.
+type Client struct{ obj *pulseaudio.Object }
+
+// NewClient returns the Client object at path.
+func NewClient(pulse *pulseaudio.Client, path dbus.ObjectPath) Client {
+	return Client{pulse.Object(ClientInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Client) Object() *pulseaudio.Object { return o.obj }
+
+// Index gets the Index property.
+func (o Client) Index() (val uint32, e error) {
+	e = o.obj.Get("Index", &val)
+	return val, e
+}
+
+// Driver gets the Driver property.
+func (o Client) Driver() (val string, e error) {
+	e = o.obj.Get("Driver", &val)
+	return val, e
+}
+
+// OwnerModule gets the OwnerModule property.
+func (o Client) OwnerModule() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("OwnerModule", &val)
+	return val, e
+}
+
+// PlaybackStreams gets the PlaybackStreams property.
+func (o Client) PlaybackStreams() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("PlaybackStreams", &val)
+	return val, e
+}
+
+// RecordStreams gets the RecordStreams property.
+func (o Client) RecordStreams() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("RecordStreams", &val)
+	return val, e
+}
+
+// PropertyList gets the PropertyList property.
+func (o Client) PropertyList() (val pulseaudio.PropertyList, e error) {
+	e = o.obj.Get("PropertyList", &val)
+	return val, e
+}
+
+// Kill calls the Kill method.
+func (o Client) Kill() error {
+	return o.obj.Call(ClientInterface+".Kill", 0).Err
+}
+
+// UpdateProperties calls the UpdateProperties method.
+func (o Client) UpdateProperties(propertyList pulseaudio.PropertyList, updateMode uint32) error {
+	return o.obj.Call(ClientInterface+".UpdateProperties", 0, propertyList, updateMode).Err
+}
+
+// RemoveProperties calls the RemoveProperties method.
+func (o Client) RemoveProperties(keys []string) error {
+	return o.obj.Call(ClientInterface+".RemoveProperties", 0, keys).Err
+}
+
+//
+//--------------------------------------------------------------------[ CORE ]--
+
+// Core wraps an object with the org.PulseAudio.Core1 interface.
+type Core struct{ obj *pulseaudio.Object }
+
+// NewCore returns the Core object at path.
+func NewCore(pulse *pulseaudio.Client, path dbus.ObjectPath) Core {
+	return Core{pulse.Object(CoreInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Core) Object() *pulseaudio.Object { return o.obj }
+
+// InterfaceRevision gets the InterfaceRevision property.
+func (o Core) InterfaceRevision() (val uint32, e error) {
+	e = o.obj.Get("InterfaceRevision", &val)
+	return val, e
+}
+
+// Name gets the Name property.
+func (o Core) Name() (val string, e error) {
+	e = o.obj.Get("Name", &val)
+	return val, e
+}
+
+// Version gets the Version property.
+func (o Core) Version() (val string, e error) {
+	e = o.obj.Get("Version", &val)
+	return val, e
+}
+
+// IsLocal gets the IsLocal property.
+func (o Core) IsLocal() (val bool, e error) {
+	e = o.obj.Get("IsLocal", &val)
+	return val, e
+}
+
+// Username gets the Username property.
+func (o Core) Username() (val string, e error) {
+	e = o.obj.Get("Username", &val)
+	return val, e
+}
+
+// Hostname gets the Hostname property.
+func (o Core) Hostname() (val string, e error) {
+	e = o.obj.Get("Hostname", &val)
+	return val, e
+}
+
+// DefaultChannels gets the DefaultChannels property.
+func (o Core) DefaultChannels() (val []uint32, e error) {
+	e = o.obj.Get("DefaultChannels", &val)
+	return val, e
+}
+
+// SetDefaultChannels sets the DefaultChannels property.
+func (o Core) SetDefaultChannels(val []uint32) error {
+	return o.obj.Set("DefaultChannels", val)
+}
+
+// DefaultSampleFormat gets the DefaultSampleFormat property.
+func (o Core) DefaultSampleFormat() (val uint32, e error) {
+	e = o.obj.Get("DefaultSampleFormat", &val)
+	return val, e
+}
+
+// SetDefaultSampleFormat sets the DefaultSampleFormat property.
+func (o Core) SetDefaultSampleFormat(val uint32) error {
+	return o.obj.Set("DefaultSampleFormat", val)
+}
+
+// DefaultSampleRate gets the DefaultSampleRate property.
+func (o Core) DefaultSampleRate() (val uint32, e error) {
+	e = o.obj.Get("DefaultSampleRate", &val)
+	return val, e
+}
+
+// SetDefaultSampleRate sets the DefaultSampleRate property.
+func (o Core) SetDefaultSampleRate(val uint32) error {
+	return o.obj.Set("DefaultSampleRate", val)
+}
+
+// AlternateSampleRate gets the AlternateSampleRate property.
+func (o Core) AlternateSampleRate() (val uint32, e error) {
+	e = o.obj.Get("AlternateSampleRate", &val)
+	return val, e
+}
+
+// SetAlternateSampleRate sets the AlternateSampleRate property.
+func (o Core) SetAlternateSampleRate(val uint32) error {
+	return o.obj.Set("AlternateSampleRate", val)
+}
+
+// Cards gets the Cards property.
+func (o Core) Cards() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Cards", &val)
+	return val, e
+}
+
+// Sinks gets the Sinks property.
+func (o Core) Sinks() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Sinks", &val)
+	return val, e
+}
+
+// FallbackSink gets the FallbackSink property.
+func (o Core) FallbackSink() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("FallbackSink", &val)
+	return val, e
+}
+
+// SetFallbackSink sets the FallbackSink property.
+func (o Core) SetFallbackSink(val dbus.ObjectPath) error {
+	return o.obj.Set("FallbackSink", val)
+}
+
+// Sources gets the Sources property.
+func (o Core) Sources() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Sources", &val)
+	return val, e
+}
+
+// FallbackSource gets the FallbackSource property.
+func (o Core) FallbackSource() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("FallbackSource", &val)
+	return val, e
+}
+
+// SetFallbackSource sets the FallbackSource property.
+func (o Core) SetFallbackSource(val dbus.ObjectPath) error {
+	return o.obj.Set("FallbackSource", val)
+}
+
+// PlaybackStreams gets the PlaybackStreams property.
+func (o Core) PlaybackStreams() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("PlaybackStreams", &val)
+	return val, e
+}
+
+// RecordStreams gets the RecordStreams property.
+func (o Core) RecordStreams() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("RecordStreams", &val)
+	return val, e
+}
+
+// Samples gets the Samples property.
+func (o Core) Samples() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Samples", &val)
+	return val, e
+}
+
+// Modules gets the Modules property.
+func (o Core) Modules() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Modules", &val)
+	return val, e
+}
+
+// Clients gets the Clients property.
+func (o Core) Clients() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Clients", &val)
+	return val, e
+}
+
+// MyClient gets the MyClient property.
+func (o Core) MyClient() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("MyClient", &val)
+	return val, e
+}
+
+// Extensions gets the Extensions property.
+func (o Core) Extensions() (val []string, e error) {
+	e = o.obj.Get("Extensions", &val)
+	return val, e
+}
+
+// GetCardByName calls the GetCardByName method.
+func (o Core) GetCardByName(name string) (card dbus.ObjectPath, e error) {
+	e = storeCall(o.obj.Call(CoreInterface+".GetCardByName", 0, name), &card)
+	return card, e
+}
+
+// GetSinkByName calls the GetSinkByName method.
+func (o Core) GetSinkByName(name string) (sink dbus.ObjectPath, e error) {
+	e = storeCall(o.obj.Call(CoreInterface+".GetSinkByName", 0, name), &sink)
+	return sink, e
+}
+
+// GetSourceByName calls the GetSourceByName method.
+func (o Core) GetSourceByName(name string) (source dbus.ObjectPath, e error) {
+	e = storeCall(o.obj.Call(CoreInterface+".GetSourceByName", 0, name), &source)
+	return source, e
+}
+
+// GetSampleByName calls the GetSampleByName method.
+func (o Core) GetSampleByName(name string) (sample dbus.ObjectPath, e error) {
+	e = storeCall(o.obj.Call(CoreInterface+".GetSampleByName", 0, name), &sample)
+	return sample, e
+}
+
+// UploadSample calls the UploadSample method.
+func (o Core) UploadSample(name string, sampleFormat uint32, sampleRate uint32, channels []uint32, defaultVolume []uint32, propertyList pulseaudio.PropertyList, data []byte) (sample dbus.ObjectPath, e error) {
+	e = storeCall(o.obj.Call(CoreInterface+".UploadSample", 0, name, sampleFormat, sampleRate, channels, defaultVolume, propertyList, data), &sample)
+	return sample, e
+}
+
+// LoadModule calls the LoadModule method.
+func (o Core) LoadModule(name string, arguments map[string]string) (module dbus.ObjectPath, e error) {
+	e = storeCall(o.obj.Call(CoreInterface+".LoadModule", 0, name, arguments), &module)
+	return module, e
+}
+
+// Exit calls the Exit method.
+func (o Core) Exit() error {
+	return o.obj.Call(CoreInterface+".Exit", 0).Err
+}
+
+// ListenForSignal calls the ListenForSignal method.
+func (o Core) ListenForSignal(signal string, objects []dbus.ObjectPath) error {
+	return o.obj.Call(CoreInterface+".ListenForSignal", 0, signal, objects).Err
+}
+
+// StopListeningForSignal calls the StopListeningForSignal method.
+func (o Core) StopListeningForSignal(signal string) error {
+	return o.obj.Call(CoreInterface+".StopListeningForSignal", 0, signal).Err
+}
+
+//
+//--------------------------------------------------------------[ DEVICEPORT ]--
+
+// DevicePort wraps an object with the org.PulseAudio.Core1.DevicePort interface.
+type DevicePort struct{ obj *pulseaudio.Object }
+
+// NewDevicePort returns the DevicePort object at path.
+func NewDevicePort(pulse *pulseaudio.Client, path dbus.ObjectPath) DevicePort {
+	return DevicePort{pulse.Object(DevicePortInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o DevicePort) Object() *pulseaudio.Object { return o.obj }
+
+// Index gets the Index property.
+func (o DevicePort) Index() (val uint32, e error) {
+	e = o.obj.Get("Index", &val)
+	return val, e
+}
+
+// Name gets the Name property.
+func (o DevicePort) Name() (val string, e error) {
+	e = o.obj.Get("Name", &val)
+	return val, e
+}
+
+// Description gets the Description property.
+func (o DevicePort) Description() (val string, e error) {
+	e = o.obj.Get("Description", &val)
+	return val, e
+}
+
+// Priority gets the Priority property.
+func (o DevicePort) Priority() (val uint32, e error) {
+	e = o.obj.Get("Priority", &val)
+	return val, e
+}
+
+// Available gets the Available property.
+func (o DevicePort) Available() (val uint32, e error) {
+	e = o.obj.Get("Available", &val)
+	return val, e
+}
+
+//
+//---------------------------------------------------------------[ EQUALIZER ]--
+
+// Equalizer wraps an object with the org.PulseAudio.Ext.Equalizing1.Equalizer interface.
+type Equalizer struct{ obj *pulseaudio.Object }
+
+// NewEqualizer returns the Equalizer object at path.
+func NewEqualizer(pulse *pulseaudio.Client, path dbus.ObjectPath) Equalizer {
+	return Equalizer{pulse.Object(EqualizerInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Equalizer) Object() *pulseaudio.Object { return o.obj }
+
+// InterfaceRevision gets the InterfaceRevision property.
+func (o Equalizer) InterfaceRevision() (val uint32, e error) {
+	e = o.obj.Get("InterfaceRevision", &val)
+	return val, e
+}
+
+// SampleRate gets the SampleRate property.
+func (o Equalizer) SampleRate() (val uint32, e error) {
+	e = o.obj.Get("SampleRate", &val)
+	return val, e
+}
+
+// FilterSampleRate gets the FilterSampleRate property.
+func (o Equalizer) FilterSampleRate() (val uint32, e error) {
+	e = o.obj.Get("FilterSampleRate", &val)
+	return val, e
+}
+
+// FilterLength gets the FilterLength property.
+func (o Equalizer) FilterLength() (val uint32, e error) {
+	e = o.obj.Get("FilterLength", &val)
+	return val, e
+}
+
+// NChannels gets the NChannels property.
+func (o Equalizer) NChannels() (val uint32, e error) {
+	e = o.obj.Get("NChannels", &val)
+	return val, e
+}
+
+// FilterAtPoints calls the FilterAtPoints method.
+func (o Equalizer) FilterAtPoints(channel uint32, xs []uint32) (ys []float64, preamp float64, e error) {
+	e = storeCall(o.obj.Call(EqualizerInterface+".FilterAtPoints", 0, channel, xs), &ys, &preamp)
+	return ys, preamp, e
+}
+
+// SeedFilter calls the SeedFilter method.
+func (o Equalizer) SeedFilter(channel uint32, xs []uint32, ys []float64, preamp float64) error {
+	return o.obj.Call(EqualizerInterface+".SeedFilter", 0, channel, xs, ys, preamp).Err
+}
+
+// SaveProfile calls the SaveProfile method.
+func (o Equalizer) SaveProfile(channel uint32, name string) error {
+	return o.obj.Call(EqualizerInterface+".SaveProfile", 0, channel, name).Err
+}
+
+// LoadProfile calls the LoadProfile method.
+func (o Equalizer) LoadProfile(channel uint32, name string) error {
+	return o.obj.Call(EqualizerInterface+".LoadProfile", 0, channel, name).Err
+}
+
+// BaseProfile calls the BaseProfile method.
+func (o Equalizer) BaseProfile(channel uint32) (name string, e error) {
+	e = storeCall(o.obj.Call(EqualizerInterface+".BaseProfile", 0, channel), &name)
+	return name, e
+}
+
+// SaveState calls the SaveState method.
+func (o Equalizer) SaveState() error {
+	return o.obj.Call(EqualizerInterface+".SaveState", 0).Err
+}
+
+//
+//--------------------------------------------------------[ EQUALIZERMANAGER ]--
+
+// EqualizerManager wraps an object with the org.PulseAudio.Ext.Equalizing1.Manager interface.
+type EqualizerManager struct{ obj *pulseaudio.Object }
+
+// NewEqualizerManager returns the EqualizerManager object at path.
+func NewEqualizerManager(pulse *pulseaudio.Client, path dbus.ObjectPath) EqualizerManager {
+	return EqualizerManager{pulse.Object(EqualizerManagerInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o EqualizerManager) Object() *pulseaudio.Object { return o.obj }
+
+// InterfaceRevision gets the InterfaceRevision property.
+func (o EqualizerManager) InterfaceRevision() (val uint32, e error) {
+	e = o.obj.Get("InterfaceRevision", &val)
+	return val, e
+}
+
+// EqualizedSinks gets the EqualizedSinks property.
+func (o EqualizerManager) EqualizedSinks() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("EqualizedSinks", &val)
+	return val, e
+}
+
+// Profiles gets the Profiles property.
+func (o EqualizerManager) Profiles() (val []string, e error) {
+	e = o.obj.Get("Profiles", &val)
+	return val, e
+}
+
+// RemoveProfile calls the RemoveProfile method.
+func (o EqualizerManager) RemoveProfile(profile string) error {
+	return o.obj.Call(EqualizerManagerInterface+".RemoveProfile", 0, profile).Err
+}
+
+//
+//------------------------------------------------------------------[ LADSPA ]--
+
+// Ladspa wraps an object with the org.PulseAudio.Ext.Ladspa1 interface.
+type Ladspa struct{ obj *pulseaudio.Object }
+
+// NewLadspa returns the Ladspa object at path.
+func NewLadspa(pulse *pulseaudio.Client, path dbus.ObjectPath) Ladspa {
+	return Ladspa{pulse.Object(LadspaInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Ladspa) Object() *pulseaudio.Object { return o.obj }
+
+// AlgorithmParameters gets the AlgorithmParameters property.
+func (o Ladspa) AlgorithmParameters() (val pulseaudio.LadspaParameters, e error) {
+	e = o.obj.Get("AlgorithmParameters", &val)
+	return val, e
+}
+
+// SetAlgorithmParameters sets the AlgorithmParameters property.
+func (o Ladspa) SetAlgorithmParameters(val pulseaudio.LadspaParameters) error {
+	return o.obj.Set("AlgorithmParameters", val)
+}
+
+//
+//----------------------------------------------------------------[ MEMSTATS ]--
+
+// Memstats wraps an object with the org.PulseAudio.Core1.Memstats interface.
+type Memstats struct{ obj *pulseaudio.Object }
+
+// NewMemstats returns the Memstats object at path.
+func NewMemstats(pulse *pulseaudio.Client, path dbus.ObjectPath) Memstats {
+	return Memstats{pulse.Object(MemstatsInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Memstats) Object() *pulseaudio.Object { return o.obj }
+
+// CurrentMemblocks gets the CurrentMemblocks property.
+func (o Memstats) CurrentMemblocks() (val uint32, e error) {
+	e = o.obj.Get("CurrentMemblocks", &val)
+	return val, e
+}
+
+// CurrentMemblocksSize gets the CurrentMemblocksSize property.
+func (o Memstats) CurrentMemblocksSize() (val uint32, e error) {
+	e = o.obj.Get("CurrentMemblocksSize", &val)
+	return val, e
+}
+
+// AccumulatedMemblocks gets the AccumulatedMemblocks property.
+func (o Memstats) AccumulatedMemblocks() (val uint32, e error) {
+	e = o.obj.Get("AccumulatedMemblocks", &val)
+	return val, e
+}
+
+// AccumulatedMemblocksSize gets the AccumulatedMemblocksSize property.
+func (o Memstats) AccumulatedMemblocksSize() (val uint32, e error) {
+	e = o.obj.Get("AccumulatedMemblocksSize", &val)
+	return val, e
+}
+
+// SampleCacheSize gets the SampleCacheSize property.
+func (o Memstats) SampleCacheSize() (val uint32, e error) {
+	e = o.obj.Get("SampleCacheSize", &val)
+	return val, e
+}
+
+//
+//------------------------------------------------------------------[ MODULE ]--
+
+// Module wraps an object with the org.PulseAudio.Core1.Module interface.
+type Module struct{ obj *pulseaudio.Object }
+
+// NewModule returns the Module object at path.
+func NewModule(pulse *pulseaudio.Client, path dbus.ObjectPath) Module {
+	return Module{pulse.Object(ModuleInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Module) Object() *pulseaudio.Object { return o.obj }
+
+// Index gets the Index property.
+func (o Module) Index() (val uint32, e error) {
+	e = o.obj.Get("Index", &val)
+	return val, e
+}
+
+// Name gets the Name property.
+func (o Module) Name() (val string, e error) {
+	e = o.obj.Get("Name", &val)
+	return val, e
+}
+
+// Arguments gets the Arguments property.
+func (o Module) Arguments() (val map[string]string, e error) {
+	e = o.obj.Get("Arguments", &val)
+	return val, e
+}
+
+// UsageCounter gets the UsageCounter property.
+func (o Module) UsageCounter() (val uint32, e error) {
+	e = o.obj.Get("UsageCounter", &val)
+	return val, e
+}
+
+// Unload calls the Unload method.
+func (o Module) Unload() error {
+	return o.obj.Call(ModuleInterface+".Unload", 0).Err
+}
+
+//
+//------------------------------------------------------------[ RESTOREENTRY ]--
+
+// RestoreEntry wraps an object with the org.PulseAudio.Ext.StreamRestore1.RestoreEntry interface.
+type RestoreEntry struct{ obj *pulseaudio.Object }
+
+// NewRestoreEntry returns the RestoreEntry object at path.
+func NewRestoreEntry(pulse *pulseaudio.Client, path dbus.ObjectPath) RestoreEntry {
+	return RestoreEntry{pulse.Object(RestoreEntryInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o RestoreEntry) Object() *pulseaudio.Object { return o.obj }
+
+// Index gets the Index property.
+func (o RestoreEntry) Index() (val uint32, e error) {
+	e = o.obj.Get("Index", &val)
+	return val, e
+}
+
+// Name gets the Name property.
+func (o RestoreEntry) Name() (val string, e error) {
+	e = o.obj.Get("Name", &val)
+	return val, e
+}
+
+// Device gets the Device property.
+func (o RestoreEntry) Device() (val string, e error) {
+	e = o.obj.Get("Device", &val)
+	return val, e
+}
+
+// SetDevice sets the Device property.
+func (o RestoreEntry) SetDevice(val string) error {
+	return o.obj.Set("Device", val)
+}
+
+// Volume gets the Volume property.
+func (o RestoreEntry) Volume() (val []pulseaudio.ChannelVolume, e error) {
+	e = o.obj.Get("Volume", &val)
+	return val, e
+}
+
+// SetVolume sets the Volume property.
+func (o RestoreEntry) SetVolume(val []pulseaudio.ChannelVolume) error {
+	return o.obj.Set("Volume", val)
+}
+
+// Mute gets the Mute property.
+func (o RestoreEntry) Mute() (val bool, e error) {
+	e = o.obj.Get("Mute", &val)
+	return val, e
+}
+
+// SetMute sets the Mute property.
+func (o RestoreEntry) SetMute(val bool) error {
+	return o.obj.Set("Mute", val)
+}
+
+// Remove calls the Remove method.
+func (o RestoreEntry) Remove() error {
+	return o.obj.Call(RestoreEntryInterface+".Remove", 0).Err
+}
+
+//
+//------------------------------------------------------------------[ SAMPLE ]--
+
+// Sample wraps an object with the org.PulseAudio.Core1.Sample interface.
+type Sample struct{ obj *pulseaudio.Object }
+
+// NewSample returns the Sample object at path.
+func NewSample(pulse *pulseaudio.Client, path dbus.ObjectPath) Sample {
+	return Sample{pulse.Object(SampleInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Sample) Object() *pulseaudio.Object { return o.obj }
+
+// Index gets the Index property.
+func (o Sample) Index() (val uint32, e error) {
+	e = o.obj.Get("Index", &val)
+	return val, e
+}
+
+// Name gets the Name property.
+func (o Sample) Name() (val string, e error) {
+	e = o.obj.Get("Name", &val)
+	return val, e
+}
+
+// SampleFormat gets the SampleFormat property.
+func (o Sample) SampleFormat() (val uint32, e error) {
+	e = o.obj.Get("SampleFormat", &val)
+	return val, e
+}
+
+// SampleRate gets the SampleRate property.
+func (o Sample) SampleRate() (val uint32, e error) {
+	e = o.obj.Get("SampleRate", &val)
+	return val, e
+}
+
+// Channels gets the Channels property.
+func (o Sample) Channels() (val []uint32, e error) {
+	e = o.obj.Get("Channels", &val)
+	return val, e
+}
+
+// DefaultVolume gets the DefaultVolume property.
+func (o Sample) DefaultVolume() (val []uint32, e error) {
+	e = o.obj.Get("DefaultVolume", &val)
+	return val, e
+}
+
+// Duration gets the Duration property.
+func (o Sample) Duration() (val uint64, e error) {
+	e = o.obj.Get("Duration", &val)
+	return val, e
+}
+
+// Bytes gets the Bytes property.
+func (o Sample) Bytes() (val uint32, e error) {
+	e = o.obj.Get("Bytes", &val)
+	return val, e
+}
+
+// PropertyList gets the PropertyList property.
+func (o Sample) PropertyList() (val pulseaudio.PropertyList, e error) {
+	e = o.obj.Get("PropertyList", &val)
+	return val, e
+}
+
+// Play calls the Play method.
+func (o Sample) Play(volume uint32, propertyList pulseaudio.PropertyList) error {
+	return o.obj.Call(SampleInterface+".Play", 0, volume, propertyList).Err
+}
+
+// PlayToSink calls the PlayToSink method.
+func (o Sample) PlayToSink(sink dbus.ObjectPath, volume uint32, propertyList pulseaudio.PropertyList) error {
+	return o.obj.Call(SampleInterface+".PlayToSink", 0, sink, volume, propertyList).Err
+}
+
+// Remove calls the Remove method.
+func (o Sample) Remove() error {
+	return o.obj.Call(SampleInterface+".Remove", 0).Err
+}
+
+//
+//------------------------------------------------------------------[ DEVICE ]--
+
+// Device wraps an object with the org.PulseAudio.Core1.Device interface.
+type Device struct{ obj *pulseaudio.Object }
+
+// NewDevice returns the Device object at path.
+func NewDevice(pulse *pulseaudio.Client, path dbus.ObjectPath) Device {
+	return Device{pulse.Object(DeviceInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Device) Object() *pulseaudio.Object { return o.obj }
+
+// Index gets the Index property.
+func (o Device) Index() (val uint32, e error) {
+	e = o.obj.Get("Index", &val)
+	return val, e
+}
+
+// Name gets the Name property.
+func (o Device) Name() (val string, e error) {
+	e = o.obj.Get("Name", &val)
+	return val, e
+}
+
+// Driver gets the Driver property.
+func (o Device) Driver() (val string, e error) {
+	e = o.obj.Get("Driver", &val)
+	return val, e
+}
+
+// OwnerModule gets the OwnerModule property.
+func (o Device) OwnerModule() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("OwnerModule", &val)
+	return val, e
+}
+
+// Card gets the Card property.
+func (o Device) Card() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("Card", &val)
+	return val, e
+}
+
+// SampleFormat gets the SampleFormat property.
+func (o Device) SampleFormat() (val uint32, e error) {
+	e = o.obj.Get("SampleFormat", &val)
+	return val, e
+}
+
+// SampleRate gets the SampleRate property.
+func (o Device) SampleRate() (val uint32, e error) {
+	e = o.obj.Get("SampleRate", &val)
+	return val, e
+}
+
+// Channels gets the Channels property.
+func (o Device) Channels() (val []uint32, e error) {
+	e = o.obj.Get("Channels", &val)
+	return val, e
+}
+
+// Volume gets the Volume property.
+func (o Device) Volume() (val []uint32, e error) {
+	e = o.obj.Get("Volume", &val)
+	return val, e
+}
+
+// SetVolume sets the Volume property.
+func (o Device) SetVolume(val []uint32) error {
+	return o.obj.Set("Volume", val)
+}
+
+// HasFlatVolume gets the HasFlatVolume property.
+func (o Device) HasFlatVolume() (val bool, e error) {
+	e = o.obj.Get("HasFlatVolume", &val)
+	return val, e
+}
+
+// HasConvertibleToDecibelVolume gets the HasConvertibleToDecibelVolume property.
+func (o Device) HasConvertibleToDecibelVolume() (val bool, e error) {
+	e = o.obj.Get("HasConvertibleToDecibelVolume", &val)
+	return val, e
+}
+
+// BaseVolume gets the BaseVolume property.
+func (o Device) BaseVolume() (val uint32, e error) {
+	e = o.obj.Get("BaseVolume", &val)
+	return val, e
+}
+
+// VolumeSteps gets the VolumeSteps property.
+func (o Device) VolumeSteps() (val uint32, e error) {
+	e = o.obj.Get("VolumeSteps", &val)
+	return val, e
+}
+
+// Mute gets the Mute property.
+func (o Device) Mute() (val bool, e error) {
+	e = o.obj.Get("Mute", &val)
+	return val, e
+}
+
+// SetMute sets the Mute property.
+func (o Device) SetMute(val bool) error {
+	return o.obj.Set("Mute", val)
+}
+
+// HasHardwareVolume gets the HasHardwareVolume property.
+func (o Device) HasHardwareVolume() (val bool, e error) {
+	e = o.obj.Get("HasHardwareVolume", &val)
+	return val, e
+}
+
+// HasHardwareMute gets the HasHardwareMute property.
+func (o Device) HasHardwareMute() (val bool, e error) {
+	e = o.obj.Get("HasHardwareMute", &val)
+	return val, e
+}
+
+// ConfiguredLatency gets the ConfiguredLatency property.
+func (o Device) ConfiguredLatency() (val uint64, e error) {
+	e = o.obj.Get("ConfiguredLatency", &val)
+	return val, e
+}
+
+// HasDynamicLatency gets the HasDynamicLatency property.
+func (o Device) HasDynamicLatency() (val bool, e error) {
+	e = o.obj.Get("HasDynamicLatency", &val)
+	return val, e
+}
+
+// Latency gets the Latency property.
+func (o Device) Latency() (val uint64, e error) {
+	e = o.obj.Get("Latency", &val)
+	return val, e
+}
+
+// IsHardwareDevice gets the IsHardwareDevice property.
+func (o Device) IsHardwareDevice() (val bool, e error) {
+	e = o.obj.Get("IsHardwareDevice", &val)
+	return val, e
+}
+
+// IsNetworkDevice gets the IsNetworkDevice property.
+func (o Device) IsNetworkDevice() (val bool, e error) {
+	e = o.obj.Get("IsNetworkDevice", &val)
+	return val, e
+}
+
+// State gets the State property.
+func (o Device) State() (val uint32, e error) {
+	e = o.obj.Get("State", &val)
+	return val, e
+}
+
+// Ports gets the Ports property.
+func (o Device) Ports() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Ports", &val)
+	return val, e
+}
+
+// ActivePort gets the ActivePort property.
+func (o Device) ActivePort() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("ActivePort", &val)
+	return val, e
+}
+
+// SetActivePort sets the ActivePort property.
+func (o Device) SetActivePort(val dbus.ObjectPath) error {
+	return o.obj.Set("ActivePort", val)
+}
+
+// PropertyList gets the PropertyList property.
+func (o Device) PropertyList() (val pulseaudio.PropertyList, e error) {
+	e = o.obj.Get("PropertyList", &val)
+	return val, e
+}
+
+// Suspend calls the Suspend method.
+func (o Device) Suspend(suspend bool) error {
+	return o.obj.Call(DeviceInterface+".Suspend", 0, suspend).Err
+}
+
+// GetPortByName calls the GetPortByName method.
+func (o Device) GetPortByName(name string) (port dbus.ObjectPath, e error) {
+	e = storeCall(o.obj.Call(DeviceInterface+".GetPortByName", 0, name), &port)
+	return port, e
+}
+
+//
+//--------------------------------------------------------------------[ SINK ]--
+
+// Sink wraps an object with the org.PulseAudio.Core1.Sink interface.
+type Sink struct{ obj *pulseaudio.Object }
+
+// NewSink returns the Sink object at path.
+func NewSink(pulse *pulseaudio.Client, path dbus.ObjectPath) Sink {
+	return Sink{pulse.Object(SinkInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Sink) Object() *pulseaudio.Object { return o.obj }
+
+// MonitorSource gets the MonitorSource property.
+func (o Sink) MonitorSource() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("MonitorSource", &val)
+	return val, e
+}
+
+//
+//------------------------------------------------------------------[ SOURCE ]--
+
+// Source wraps an object with the org.PulseAudio.Core1.Source interface.
+type Source struct{ obj *pulseaudio.Object }
+
+// NewSource returns the Source object at path.
+func NewSource(pulse *pulseaudio.Client, path dbus.ObjectPath) Source {
+	return Source{pulse.Object(SourceInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Source) Object() *pulseaudio.Object { return o.obj }
+
+// MonitorOfSink gets the MonitorOfSink property.
+func (o Source) MonitorOfSink() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("MonitorOfSink", &val)
+	return val, e
+}
+
+//
+//------------------------------------------------------------------[ STREAM ]--
+
+// Stream wraps an object with the org.PulseAudio.Core1.Stream interface.
+type Stream struct{ obj *pulseaudio.Object }
+
+// NewStream returns the Stream object at path.
+func NewStream(pulse *pulseaudio.Client, path dbus.ObjectPath) Stream {
+	return Stream{pulse.Object(StreamInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o Stream) Object() *pulseaudio.Object { return o.obj }
+
+// Index gets the Index property.
+func (o Stream) Index() (val uint32, e error) {
+	e = o.obj.Get("Index", &val)
+	return val, e
+}
+
+// Driver gets the Driver property.
+func (o Stream) Driver() (val string, e error) {
+	e = o.obj.Get("Driver", &val)
+	return val, e
+}
+
+// OwnerModule gets the OwnerModule property.
+func (o Stream) OwnerModule() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("OwnerModule", &val)
+	return val, e
+}
+
+// Client gets the Client property.
+func (o Stream) Client() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("Client", &val)
+	return val, e
+}
+
+// Device gets the Device property.
+func (o Stream) Device() (val dbus.ObjectPath, e error) {
+	e = o.obj.Get("Device", &val)
+	return val, e
+}
+
+// SampleFormat gets the SampleFormat property.
+func (o Stream) SampleFormat() (val uint32, e error) {
+	e = o.obj.Get("SampleFormat", &val)
+	return val, e
+}
+
+// SampleRate gets the SampleRate property.
+func (o Stream) SampleRate() (val uint32, e error) {
+	e = o.obj.Get("SampleRate", &val)
+	return val, e
+}
+
+// Channels gets the Channels property.
+func (o Stream) Channels() (val []uint32, e error) {
+	e = o.obj.Get("Channels", &val)
+	return val, e
+}
+
+// Volume gets the Volume property.
+func (o Stream) Volume() (val []uint32, e error) {
+	e = o.obj.Get("Volume", &val)
+	return val, e
+}
+
+// SetVolume sets the Volume property.
+func (o Stream) SetVolume(val []uint32) error {
+	return o.obj.Set("Volume", val)
+}
+
+// VolumeWritable gets the VolumeWritable property.
+func (o Stream) VolumeWritable() (val bool, e error) {
+	e = o.obj.Get("VolumeWritable", &val)
+	return val, e
+}
+
+// Mute gets the Mute property.
+func (o Stream) Mute() (val bool, e error) {
+	e = o.obj.Get("Mute", &val)
+	return val, e
+}
+
+// SetMute sets the Mute property.
+func (o Stream) SetMute(val bool) error {
+	return o.obj.Set("Mute", val)
+}
+
+// BufferLatency gets the BufferLatency property.
+func (o Stream) BufferLatency() (val uint64, e error) {
+	e = o.obj.Get("BufferLatency", &val)
+	return val, e
+}
+
+// DeviceLatency gets the DeviceLatency property.
+func (o Stream) DeviceLatency() (val uint64, e error) {
+	e = o.obj.Get("DeviceLatency", &val)
+	return val, e
+}
+
+// ResampleMethod gets the ResampleMethod property.
+func (o Stream) ResampleMethod() (val string, e error) {
+	e = o.obj.Get("ResampleMethod", &val)
+	return val, e
+}
+
+// PropertyList gets the PropertyList property.
+func (o Stream) PropertyList() (val pulseaudio.PropertyList, e error) {
+	e = o.obj.Get("PropertyList", &val)
+	return val, e
+}
+
+// Kill calls the Kill method.
+func (o Stream) Kill() error {
+	return o.obj.Call(StreamInterface+".Kill", 0).Err
+}
+
+// Move calls the Move method.
+func (o Stream) Move(device dbus.ObjectPath) error {
+	return o.obj.Call(StreamInterface+".Move", 0, device).Err
+}
+
+//
+//-----------------------------------------------------------[ STREAMRESTORE ]--
+
+// StreamRestore wraps an object with the org.PulseAudio.Ext.StreamRestore1 interface.
+type StreamRestore struct{ obj *pulseaudio.Object }
+
+// NewStreamRestore returns the StreamRestore object at path.
+func NewStreamRestore(pulse *pulseaudio.Client, path dbus.ObjectPath) StreamRestore {
+	return StreamRestore{pulse.Object(StreamRestoreInterface, path)}
+}
+
+// Object returns the Dbus object, to access properties by name.
+func (o StreamRestore) Object() *pulseaudio.Object { return o.obj }
+
+// InterfaceRevision gets the InterfaceRevision property.
+func (o StreamRestore) InterfaceRevision() (val uint32, e error) {
+	e = o.obj.Get("InterfaceRevision", &val)
+	return val, e
+}
+
+// Entries gets the Entries property.
+func (o StreamRestore) Entries() (val []dbus.ObjectPath, e error) {
+	e = o.obj.Get("Entries", &val)
+	return val, e
+}
+
+// AddEntry calls the AddEntry method.
+func (o StreamRestore) AddEntry(name string, device string, volume []pulseaudio.ChannelVolume, mute bool, applyImmediately bool) (entry dbus.ObjectPath, e error) {
+	e = storeCall(o.obj.Call(StreamRestoreInterface+".AddEntry", 0, name, device, volume, mute, applyImmediately), &entry)
+	return entry, e
+}
+
+// GetEntryByName calls the GetEntryByName method.
+func (o StreamRestore) GetEntryByName(name string) (entry dbus.ObjectPath, e error) {
+	e = storeCall(o.obj.Call(StreamRestoreInterface+".GetEntryByName", 0, name), &entry)
+	return entry, e
+}
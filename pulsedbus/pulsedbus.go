@@ -0,0 +1,47 @@
+// Package pulsedbus provides typed bindings for the pulseaudio Dbus
+// interfaces, generated by pulsegen from the introspection XML files in the
+// xml directory of the pulseaudio package.
+//
+// Every interface has an object type with its properties getters (and setters
+// when writable) and its methods:
+//   sink := pulsedbus.NewDevice(pulse, path)
+//   vol, e := sink.Volume()
+//   e = sink.SetMute(true)
+//
+// Signals are declared in the pulseaudio package, generated from the same
+// files (see PulseEvents).
+//
+package pulsedbus
+
+//go:generate go run ../cmd/pulsegen -pkg pulsedbus -out generated.go ../xml
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"fmt"
+)
+
+// storeCall stores the results of a method call in dest, converted like
+// properties.
+//
+func storeCall(call *dbus.Call, dest ...interface{}) error {
+	if call.Err != nil {
+		return call.Err
+	}
+	return storeList(call.Body, dest)
+}
+
+func storeList(values []interface{}, dest []interface{}) error {
+	if len(values) < len(dest) {
+		return fmt.Errorf("store: got %d values, want %d", len(values), len(dest))
+	}
+	for i, d := range dest {
+		e := pulseaudio.StoreValue(values[i], d)
+		if e != nil {
+			return e
+		}
+	}
+	return nil
+}
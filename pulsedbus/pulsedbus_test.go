@@ -0,0 +1,39 @@
+package pulsedbus
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestStoreCall(t *testing.T) {
+	var ys []float64
+	var preamp float64
+	call := &dbus.Call{Body: []interface{}{[]float64{0.5, 1}, float64(2)}}
+	e := storeCall(call, &ys, &preamp)
+	if e != nil || !reflect.DeepEqual(ys, []float64{0.5, 1}) || preamp != 2 {
+		t.Errorf("store: got %v %v, %v", ys, preamp, e)
+	}
+
+	var volume []pulseaudio.ChannelVolume
+	call = &dbus.Call{Body: []interface{}{[]interface{}{[]interface{}{uint32(1), uint32(100)}}}}
+	e = storeCall(call, &volume)
+	if want := []pulseaudio.ChannelVolume{{Channel: 1, Volume: 100}}; e != nil || !reflect.DeepEqual(volume, want) {
+		t.Errorf("store struct: got %v, %v, want %v", volume, e, want)
+	}
+
+	callErr := errors.New("call failed")
+	if e = storeCall(&dbus.Call{Err: callErr}, &preamp); e != callErr {
+		t.Errorf("call error: got %v", e)
+	}
+	if e = storeCall(&dbus.Call{Body: []interface{}{float64(1)}}, &ys, &preamp); e == nil {
+		t.Error("missing value: expected an error")
+	}
+	if e = storeCall(&dbus.Call{Body: []interface{}{"text"}}, &preamp); e == nil {
+		t.Error("wrong type: expected an error")
+	}
+}
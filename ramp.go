@@ -0,0 +1,225 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// RampInterval is the time between two volume writes of a ramp.
+var RampInterval = 20 * time.Millisecond
+
+// ErrRampCanceled is returned when a volume ramp is replaced by a new ramp on
+// the same object.
+var ErrRampCanceled = errors.New("volume ramp canceled by a new ramp")
+
+// rampMinDB is the level used as silence by the dB-linear curve.
+const rampMinDB = -90.
+
+// RampCurve defines the progression of a volume ramp.
+//
+type RampCurve int
+
+// Volume ramp curves.
+//
+const (
+	RampLinear      RampCurve = iota // Linear on the pulseaudio volume scale (already perceptual).
+	RampLogarithmic                  // Fast at start, slow at the end.
+	RampDBLinear                     // Linear in decibels, for long fades.
+)
+
+// RampVolume changes the device volume progressively to target over duration,
+// following the curve. Target can hold one volume per channel, or a single
+// volume for all channels.
+//
+// The volume is written every RampInterval, starting one interval after the
+// call. The call blocks until the ramp ends, the context is done, or a new
+// ramp is started on the device (ErrRampCanceled). Devices with coarse
+// hardware volume are only written to their VolumeSteps.
+//
+func (dev *Device) RampVolume(ctx context.Context, target []uint32, duration time.Duration, curve RampCurve) error {
+	steps, e := dev.Uint32("VolumeSteps")
+	if e != nil {
+		steps = 0 // Optional property.
+	}
+	return dev.pulse.rampVolume(ctx, dev.Object, steps, target, duration, curve)
+}
+
+// RampVolume changes the stream volume progressively to target over duration,
+// following the curve. See Device.RampVolume.
+//
+func (stream *Stream) RampVolume(ctx context.Context, target []uint32, duration time.Duration, curve RampCurve) error {
+	return stream.pulse.rampVolume(ctx, stream.Object, 0, target, duration, curve)
+}
+
+// rampVolume writes the intermediate volumes of a ramp.
+// Volumes are rounded to steps if set and lower than the full volume scale.
+//
+func (pulse *Client) rampVolume(ctx context.Context, obj *Object, steps uint32, target []uint32, duration time.Duration, curve RampCurve) error {
+	from, e := obj.ListUint32("Volume")
+	if e != nil {
+		return e
+	}
+	if len(target) == 1 && len(from) > 1 {
+		single := target[0]
+		target = make([]uint32, len(from))
+		for i := range target {
+			target[i] = single
+		}
+	}
+	if len(target) != len(from) {
+		return fmt.Errorf("ramp volume: got %d channels, want %d or 1", len(target), len(from))
+	}
+	return pulse.ramp(ctx, obj.Path(), from, target, duration, curve, steps, func(vol []uint32) error {
+		return obj.Set("Volume", vol)
+	})
+}
+
+// ramp calls set with the intermediate volumes of a ramp, one every
+// RampInterval, the first after one interval so the target is reached at the
+// end of the duration. A duration shorter than RampInterval sets the target
+// immediately. The ramp is registered on the object path, to be canceled by a
+// new ramp on the object.
+//
+func (pulse *Client) ramp(ctx context.Context, path dbus.ObjectPath, from, target []uint32, duration time.Duration, curve RampCurve, steps uint32, set func([]uint32) error) error {
+	stop := pulse.startRamp(path)
+	defer pulse.endRamp(path, stop)
+
+	count := int(duration / RampInterval)
+	if count < 1 {
+		return pulse.rampSet(path, stop, target, set) // Too short to ramp.
+	}
+	ticker := time.NewTicker(RampInterval)
+	defer ticker.Stop()
+
+	var last []uint32
+	for i := 1; i <= count; i++ {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-stop:
+			return ErrRampCanceled
+		}
+
+		vol := target
+		if i < count {
+			vol = RampPoint(from, target, float64(i)/float64(count), curve)
+			if steps > 1 && steps <= VolumeNorm {
+				vol = quantizeVolume(vol, steps)
+			}
+		}
+		if last != nil && equalUint32(vol, last) {
+			continue
+		}
+		if e := pulse.rampSet(path, stop, vol, set); e != nil {
+			return e
+		}
+		last = vol
+	}
+	return nil
+}
+
+// startRamp registers a new ramp on the object, cancelling the previous one.
+//
+func (pulse *Client) startRamp(path dbus.ObjectPath) chan struct{} {
+	pulse.rampMu.Lock()
+	defer pulse.rampMu.Unlock()
+	if stop, ok := pulse.ramps[path]; ok {
+		close(stop)
+	}
+	stop := make(chan struct{})
+	pulse.ramps[path] = stop
+	return stop
+}
+
+// rampSet writes a step of a ramp, unless it was replaced. The registry stays
+// locked during the write, so a new ramp can't be overwritten by a late step.
+//
+func (pulse *Client) rampSet(path dbus.ObjectPath, stop chan struct{}, vol []uint32, set func([]uint32) error) error {
+	pulse.rampMu.Lock()
+	defer pulse.rampMu.Unlock()
+	if pulse.ramps[path] != stop {
+		return ErrRampCanceled
+	}
+	return set(vol)
+}
+
+// endRamp unregisters a ramp, if it wasn't replaced.
+//
+func (pulse *Client) endRamp(path dbus.ObjectPath, stop chan struct{}) {
+	pulse.rampMu.Lock()
+	defer pulse.rampMu.Unlock()
+	if pulse.ramps[path] == stop {
+		delete(pulse.ramps, path)
+	}
+}
+
+// RampPoint returns the channel volumes at pos (0 to 1) of a ramp from from to
+// to, following the curve.
+//
+func RampPoint(from, to []uint32, pos float64, curve RampCurve) []uint32 {
+	switch {
+	case pos <= 0:
+		pos = 0
+	case pos >= 1:
+		pos = 1
+	}
+
+	switch curve {
+	case RampLogarithmic:
+		return interpolateVolume(from, to, math.Log10(1+9*pos))
+
+	case RampDBLinear:
+		out := make([]uint32, len(to))
+		for i := range to {
+			start := to[i]
+			if i < len(from) {
+				start = from[i]
+			}
+			db := volumeToDB(start) + (volumeToDB(to[i])-volumeToDB(start))*pos
+			out[i] = dbToVolume(db)
+			if pos == 1 {
+				out[i] = to[i]
+			}
+		}
+		return out
+	}
+	return interpolateVolume(from, to, pos)
+}
+
+// volumeToDB converts a pulseaudio volume to decibels, like pa_sw_volume_to_dB.
+// Silence is returned as rampMinDB.
+//
+func volumeToDB(vol uint32) float64 {
+	if vol == 0 {
+		return rampMinDB
+	}
+	db := 60 * math.Log10(float64(vol)/float64(VolumeNorm)) // Volumes are cubic.
+	return math.Max(db, rampMinDB)
+}
+
+// dbToVolume converts decibels to a pulseaudio volume, like pa_sw_volume_from_dB.
+//
+func dbToVolume(db float64) uint32 {
+	if db <= rampMinDB {
+		return 0
+	}
+	return clampVolume(float64(VolumeNorm) * math.Pow(10, db/60))
+}
+
+// quantizeVolume rounds the volumes to the nearest of steps values between
+// silence and VolumeNorm.
+//
+func quantizeVolume(vol []uint32, steps uint32) []uint32 {
+	step := float64(VolumeNorm) / float64(steps-1)
+	out := make([]uint32, len(vol))
+	for i, v := range vol {
+		out[i] = clampVolume(math.Round(float64(v)/step) * step)
+	}
+	return out
+}
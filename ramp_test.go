@@ -0,0 +1,108 @@
+package pulseaudio_test
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRampPoint(t *testing.T) {
+	from, to := []uint32{0, 65536}, []uint32{65536, 0}
+	for _, test := range []struct {
+		curve pulseaudio.RampCurve
+		pos   float64
+		want  []uint32
+	}{
+		{pulseaudio.RampLinear, 0, []uint32{0, 65536}},
+		{pulseaudio.RampLinear, 0.5, []uint32{32768, 32768}},
+		{pulseaudio.RampLinear, 2, []uint32{65536, 0}},
+		{pulseaudio.RampLogarithmic, 0.5, []uint32{48520, 17016}},
+		{pulseaudio.RampLogarithmic, 1, []uint32{65536, 0}},
+		{pulseaudio.RampDBLinear, 0.5, []uint32{11654, 11654}}, // -45 dB.
+		{pulseaudio.RampDBLinear, 1, []uint32{65536, 0}},
+	} {
+		got := pulseaudio.RampPoint(from, to, test.pos, test.curve)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ramp point curve %d at %v: got %v, want %v", test.curve, test.pos, got, test.want)
+		}
+	}
+
+	// dB-linear ramps are monotonic.
+	var last uint32
+	for i := 0; i <= 100; i++ {
+		vol := pulseaudio.RampPoint([]uint32{0}, []uint32{65536}, float64(i)/100, pulseaudio.RampDBLinear)[0]
+		if vol < last {
+			t.Fatalf("dB-linear ramp at %d%%: %d lower than %d", i, vol, last)
+		}
+		last = vol
+	}
+}
+
+func TestRampVolume(t *testing.T) {
+	pulse := pulseaudio.NewReplayClient()
+	fs := newFakeServer(pulse)
+	fs.set("/sink0", "Volume", []uint32{0})
+	fs.set("/sink0", "VolumeSteps", uint32(3))
+
+	// Volumes are rounded to the steps of the device, and the target is set
+	// at the end of the duration.
+	duration := 8 * pulseaudio.RampInterval
+	begin := time.Now()
+	e := pulse.TypedDevice("/sink0").RampVolume(context.Background(), []uint32{65536}, duration, pulseaudio.RampLinear)
+	if elapsed := time.Since(begin); e != nil || elapsed < duration {
+		t.Errorf("ramp: ended after %s (error %v), want %s", elapsed, e, duration)
+	}
+	want := []string{"/sink0 Set Volume [0]", "/sink0 Set Volume [32768]", "/sink0 Set Volume [65536]"}
+	if calls := fs.takeCalls(); !reflect.DeepEqual(calls, want) {
+		t.Errorf("steps: got calls %v, want %v", calls, want)
+	}
+
+	// The target must have one volume per channel, or a single volume.
+	fs.set("/stream0", "Volume", []uint32{65536, 65536})
+	stream := pulse.TypedStream("/stream0")
+	if e := stream.RampVolume(context.Background(), []uint32{0, 0, 0}, 0, pulseaudio.RampLinear); e == nil {
+		t.Error("channels: want an error for 3 volumes on a stereo stream")
+	}
+	if e := stream.RampVolume(context.Background(), []uint32{0}, 0, pulseaudio.RampLinear); e != nil {
+		t.Error("single volume:", e)
+	}
+	want = []string{"/stream0 Set Volume [0 0]"}
+	if calls := fs.takeCalls(); !reflect.DeepEqual(calls, want) {
+		t.Errorf("channels: got calls %v, want %v", calls, want)
+	}
+}
+
+func TestRampVolumeCancel(t *testing.T) {
+	pulse := pulseaudio.NewReplayClient()
+	fs := newFakeServer(pulse)
+	fs.set("/stream0", "Volume", []uint32{65536})
+	stream := pulse.TypedStream("/stream0")
+
+	done := make(chan error)
+	go func() {
+		done <- stream.RampVolume(context.Background(), []uint32{0}, 50*pulseaudio.RampInterval, pulseaudio.RampLinear)
+	}()
+	time.Sleep(3 * pulseaudio.RampInterval)
+
+	// A new ramp on the object cancels the running one.
+	if e := stream.RampVolume(context.Background(), []uint32{30000}, 0, pulseaudio.RampLinear); e != nil {
+		t.Error("new ramp:", e)
+	}
+	if e := <-done; e != pulseaudio.ErrRampCanceled {
+		t.Errorf("canceled ramp: got %v, want %v", e, pulseaudio.ErrRampCanceled)
+	}
+	time.Sleep(2 * pulseaudio.RampInterval)
+	if got := fs.get("/stream0", "Volume"); !reflect.DeepEqual(got, []uint32{30000}) {
+		t.Errorf("volume: got %v, want the new ramp target", got)
+	}
+
+	// A canceled context stops the ramp.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if e := stream.RampVolume(ctx, []uint32{0}, 10*pulseaudio.RampInterval, pulseaudio.RampLinear); e != context.Canceled {
+		t.Errorf("context: got %v, want %v", e, context.Canceled)
+	}
+}
@@ -0,0 +1,555 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Replay speeds.
+const (
+	ReplayInstant  = 0 // Dispatch the signals without delay.
+	ReplayRealTime = 1 // Dispatch the signals with the recorded delays.
+)
+
+// RecordedSignal is a signal saved by a Recorder.
+//
+// In the record file, signals are written as one JSON object by line. The
+// body arguments are saved with their Dbus signature, to be decoded with the
+// same types. Byte arrays are base64 encoded, structs are JSON arrays, and
+// variants are objects with type and value like body arguments:
+//
+//   {"time":"2018-11-20T21:10:52.39+01:00","name":"org.PulseAudio.Core1.Device.VolumeUpdated",
+//    "path":"/org/pulseaudio/core1/sink0","body":[{"type":"au","value":[42000,42000]}]}
+//
+type RecordedSignal struct {
+	Time time.Time
+	Name string
+	Path dbus.ObjectPath
+	Body []interface{}
+}
+
+// recordedJSON is the file format of a recorded signal.
+//
+type recordedJSON struct {
+	Time time.Time       `json:"time"`
+	Name string          `json:"name"`
+	Path dbus.ObjectPath `json:"path"`
+	Body []recordedArg   `json:"body,omitempty"`
+}
+
+// recordedArg is the file format of a signal body argument.
+//
+type recordedArg struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Signal returns the signal to dispatch.
+//
+func (rs RecordedSignal) Signal() *dbus.Signal {
+	return &dbus.Signal{Name: rs.Name, Path: rs.Path, Body: rs.Body}
+}
+
+// MarshalJSON encodes the signal in the record file format.
+//
+func (rs RecordedSignal) MarshalJSON() ([]byte, error) {
+	rj := recordedJSON{Time: rs.Time, Name: rs.Name, Path: rs.Path}
+	for _, arg := range rs.Body {
+		ra, e := encodeArg(valueSignature(reflect.ValueOf(arg)), reflect.ValueOf(arg))
+		if e != nil {
+			return nil, e
+		}
+		rj.Body = append(rj.Body, ra)
+	}
+	return json.Marshal(rj)
+}
+
+// UnmarshalJSON decodes the signal from the record file format.
+//
+func (rs *RecordedSignal) UnmarshalJSON(data []byte) error {
+	var rj recordedJSON
+	e := json.Unmarshal(data, &rj)
+	if e != nil {
+		return e
+	}
+	body := make([]interface{}, len(rj.Body))
+	for i, ra := range rj.Body {
+		v, e := decodeValue(ra.Type, ra.Value)
+		if e != nil {
+			return fmt.Errorf("signal %s arg %d: %v", rj.Name, i, e)
+		}
+		body[i] = v.Interface()
+	}
+	*rs = RecordedSignal{Time: rj.Time, Name: rj.Name, Path: rj.Path, Body: body}
+	return nil
+}
+
+// encodeArg encodes a value with its signature.
+//
+func encodeArg(sig string, v reflect.Value) (recordedArg, error) {
+	e := checkSignature(sig)
+	if e != nil {
+		return recordedArg{}, e
+	}
+	value, e := encodeValue(sig, v)
+	if e != nil {
+		return recordedArg{}, e
+	}
+	data, e := json.Marshal(value)
+	return recordedArg{Type: sig, Value: data}, e
+}
+
+// encodeValue converts a Dbus value to its JSON representation.
+// The signature must have been checked with checkSignature.
+//
+func encodeValue(sig string, v reflect.Value) (interface{}, error) {
+	if !strings.ContainsAny(sig, "v({") {
+		return v.Interface(), nil // Basic types and arrays are handled by JSON.
+	}
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	switch sig[0] {
+	case 'v':
+		variant := v.Interface().(dbus.Variant)
+		return encodeArg(variant.Signature().String(), reflect.ValueOf(variant.Value()))
+
+	case '(':
+		fields := splitSignature(sig[1 : len(sig)-1])
+		list := make([]interface{}, len(fields))
+		for i, field := range fields {
+			var fv reflect.Value
+			if v.Kind() == reflect.Struct {
+				fv = v.Field(i)
+			} else {
+				fv = v.Index(i)
+			}
+			value, e := encodeValue(field, fv)
+			if e != nil {
+				return nil, e
+			}
+			list[i] = value
+		}
+		return list, nil
+
+	case 'a':
+		if sig[1] == '{' {
+			kv := splitSignature(sig[2 : len(sig)-1])
+			m := make(map[string]interface{}, v.Len())
+			for _, key := range v.MapKeys() {
+				value, e := encodeValue(kv[1], v.MapIndex(key))
+				if e != nil {
+					return nil, e
+				}
+				m[fmt.Sprint(key.Interface())] = value
+			}
+			return m, nil
+		}
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			value, e := encodeValue(sig[1:], v.Index(i))
+			if e != nil {
+				return nil, e
+			}
+			list[i] = value
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("unsupported signature %q", sig)
+}
+
+// decodeValue converts a JSON representation to a Dbus value.
+//
+func decodeValue(sig string, data json.RawMessage) (reflect.Value, error) {
+	e := checkSignature(sig)
+	if e != nil {
+		return reflect.Value{}, e
+	}
+	typ, e := signatureType(sig)
+	if e != nil {
+		return reflect.Value{}, e
+	}
+	if !strings.ContainsAny(sig, "v({") {
+		ptr := reflect.New(typ)
+		e := json.Unmarshal(data, ptr.Interface())
+		return ptr.Elem(), e
+	}
+
+	switch sig[0] {
+	case 'v':
+		var ra recordedArg
+		e := json.Unmarshal(data, &ra)
+		if e != nil {
+			return reflect.Value{}, e
+		}
+		value, e := decodeValue(ra.Type, ra.Value)
+		if e != nil {
+			return reflect.Value{}, e
+		}
+		vsig, e := dbus.ParseSignature(ra.Type)
+		if e != nil {
+			return reflect.Value{}, e
+		}
+		variant := dbus.MakeVariantWithSignature(value.Interface(), vsig)
+		return reflect.ValueOf(variant), nil
+
+	case '(':
+		var raws []json.RawMessage
+		e := json.Unmarshal(data, &raws)
+		if e != nil {
+			return reflect.Value{}, e
+		}
+		fields := splitSignature(sig[1 : len(sig)-1])
+		if len(raws) != len(fields) {
+			return reflect.Value{}, fmt.Errorf("struct %s: got %d fields", sig, len(raws))
+		}
+		list := make([]interface{}, len(fields))
+		for i, field := range fields {
+			value, e := decodeValue(field, raws[i])
+			if e != nil {
+				return reflect.Value{}, e
+			}
+			list[i] = value.Interface()
+		}
+		return reflect.ValueOf(list), nil
+
+	case 'a':
+		if sig[1] == '{' {
+			var raws map[string]json.RawMessage
+			e := json.Unmarshal(data, &raws)
+			if e != nil {
+				return reflect.Value{}, e
+			}
+			kv := splitSignature(sig[2 : len(sig)-1])
+			m := reflect.MakeMapWithSize(typ, len(raws))
+			for str, raw := range raws {
+				key := reflect.New(typ.Key()).Elem()
+				if key.Kind() == reflect.String {
+					key.SetString(str)
+				} else if e := json.Unmarshal([]byte(str), key.Addr().Interface()); e != nil {
+					return reflect.Value{}, e
+				}
+				value, e := decodeValue(kv[1], raw)
+				if e != nil {
+					return reflect.Value{}, e
+				}
+				m.SetMapIndex(key, value)
+			}
+			return m, nil
+		}
+		var raws []json.RawMessage
+		e := json.Unmarshal(data, &raws)
+		if e != nil {
+			return reflect.Value{}, e
+		}
+		list := reflect.MakeSlice(typ, len(raws), len(raws))
+		for i, raw := range raws {
+			value, e := decodeValue(sig[1:], raw)
+			if e != nil {
+				return reflect.Value{}, e
+			}
+			list.Index(i).Set(value)
+		}
+		return list, nil
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported signature %q", sig)
+}
+
+// valueSignature returns the Dbus signature of a value. Unlike
+// dbus.SignatureOf, lists of interfaces are structs, as decoded by Dbus.
+// Empty lists have no value to learn the struct fields from, they are recorded
+// with an empty struct so they are replayed as lists of interfaces.
+//
+func valueSignature(v reflect.Value) string {
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	typ := v.Type()
+	switch {
+	case typ == interfacesType:
+		sig := "("
+		for i := 0; i < v.Len(); i++ {
+			sig += valueSignature(v.Index(i))
+		}
+		return sig + ")"
+
+	case typ.Kind() == reflect.Slice && v.Len() > 0 && hasInterfaces(typ.Elem()):
+		return "a" + valueSignature(v.Index(0))
+
+	case typ.Kind() == reflect.Map && v.Len() > 0 && hasInterfaces(typ.Elem()):
+		key := v.MapKeys()[0]
+		return "a{" + dbus.SignatureOfType(typ.Key()).String() + valueSignature(v.MapIndex(key)) + "}"
+	}
+	return typeSignature(typ)
+}
+
+// typeSignature returns the Dbus signature of a type, with lists of interfaces
+// as empty structs.
+//
+func typeSignature(typ reflect.Type) string {
+	switch {
+	case typ == interfacesType:
+		return "()"
+
+	case typ.Kind() == reflect.Slice && hasInterfaces(typ.Elem()):
+		return "a" + typeSignature(typ.Elem())
+
+	case typ.Kind() == reflect.Map && hasInterfaces(typ.Elem()):
+		return "a{" + dbus.SignatureOfType(typ.Key()).String() + typeSignature(typ.Elem()) + "}"
+	}
+	return dbus.SignatureOfType(typ).String()
+}
+
+// checkSignature returns an error if sig isn't a valid single complete type
+// signature, so it can be sliced safely by the encoder and decoder.
+//
+func checkSignature(sig string) error {
+	if sig == "" {
+		return fmt.Errorf("empty signature")
+	}
+	if strings.Contains(sig, "{}") { // Would panic in dbus.ParseSignature.
+		return fmt.Errorf("invalid dict signature %q", sig)
+	}
+	_, e := dbus.ParseSignature(sig)
+	if e != nil {
+		return e
+	}
+	if len(splitSignature(sig)) != 1 {
+		return fmt.Errorf("signature %q isn't a single complete type", sig)
+	}
+	return nil
+}
+
+// hasInterfaces returns whether the type contains lists of interfaces.
+//
+func hasInterfaces(typ reflect.Type) bool {
+	switch {
+	case typ == interfacesType:
+		return true
+	case typ.Kind() == reflect.Slice, typ.Kind() == reflect.Map:
+		return hasInterfaces(typ.Elem())
+	}
+	return false
+}
+
+var interfacesType = reflect.TypeOf([]interface{}{})
+
+// basicTypes are the Go types of the Dbus basic signatures.
+//
+var basicTypes = map[byte]reflect.Type{
+	'y': reflect.TypeOf(byte(0)),
+	'b': reflect.TypeOf(false),
+	'n': reflect.TypeOf(int16(0)),
+	'q': reflect.TypeOf(uint16(0)),
+	'i': reflect.TypeOf(int32(0)),
+	'u': reflect.TypeOf(uint32(0)),
+	'x': reflect.TypeOf(int64(0)),
+	't': reflect.TypeOf(uint64(0)),
+	'd': reflect.TypeOf(float64(0)),
+	's': reflect.TypeOf(""),
+	'o': reflect.TypeOf(dbus.ObjectPath("")),
+	'g': reflect.TypeOf(dbus.Signature{}),
+	'h': reflect.TypeOf(dbus.UnixFDIndex(0)),
+	'v': variantType,
+	'(': interfacesType, // Structs are decoded as lists.
+}
+
+// signatureType returns the Go type used by Dbus to decode a single complete
+// type signature.
+//
+func signatureType(sig string) (reflect.Type, error) {
+	if sig == "" {
+		return nil, fmt.Errorf("empty signature")
+	}
+	if typ, ok := basicTypes[sig[0]]; ok {
+		return typ, nil
+	}
+	if sig[0] != 'a' || len(sig) < 2 {
+		return nil, fmt.Errorf("unsupported signature %q", sig)
+	}
+	if sig[1] == '{' {
+		if sig[len(sig)-1] != '}' {
+			return nil, fmt.Errorf("invalid dict signature %q", sig)
+		}
+		kv := splitSignature(sig[2 : len(sig)-1])
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid dict signature %q", sig)
+		}
+		key, e := signatureType(kv[0])
+		if e != nil {
+			return nil, e
+		}
+		value, e := signatureType(kv[1])
+		if e != nil {
+			return nil, e
+		}
+		return reflect.MapOf(key, value), nil
+	}
+	elem, e := signatureType(sig[1:])
+	if e != nil {
+		return nil, e
+	}
+	return reflect.SliceOf(elem), nil
+}
+
+// splitSignature splits a signature in its single complete types.
+//
+func splitSignature(sig string) (list []string) {
+	for sig != "" {
+		n := 0
+		for n < len(sig) && sig[n] == 'a' {
+			n++
+		}
+		if n < len(sig) && (sig[n] == '(' || sig[n] == '{') {
+			depth := 0
+			for {
+				switch sig[n] {
+				case '(', '{':
+					depth++
+				case ')', '}':
+					depth--
+				}
+				n++
+				if depth == 0 || n == len(sig) {
+					break
+				}
+			}
+		} else if n < len(sig) {
+			n++
+		}
+		list = append(list, sig[:n])
+		sig = sig[n:]
+	}
+	return list
+}
+
+//
+//----------------------------------------------------------------[ RECORDER ]--
+
+// Recorder saves the signals dispatched by a client, to replay them later
+// with Replay.
+//
+// The recorder only sees the signals that reach its position in the
+// middlewares chain, so add it first to get all signals.
+//
+//   file, _ := os.Create("signals.jsonl")
+//   rec := pulseaudio.NewRecorder(file)
+//   pulse.Use(rec.Middleware)
+//
+type Recorder struct {
+	OnError func(error) // Optional. Write errors are ignored when not set.
+
+	enc *json.Encoder
+	mu  sync.Mutex
+}
+
+// NewRecorder creates a recorder writing the signals to w.
+//
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Middleware records the signals, to use with Client.Use.
+//
+func (rec *Recorder) Middleware(next Dispatcher) Dispatcher {
+	return func(s *dbus.Signal) {
+		rec.Record(s)
+		next(s)
+	}
+}
+
+// Record saves a signal.
+//
+func (rec *Recorder) Record(s *dbus.Signal) {
+	rs := RecordedSignal{
+		Name: s.Name,
+		Path: s.Path,
+		Body: s.Body,
+	}
+	rec.mu.Lock()
+	rs.Time = time.Now()
+	e := rec.enc.Encode(rs)
+	rec.mu.Unlock()
+
+	if e != nil && rec.OnError != nil {
+		rec.OnError(e)
+	}
+}
+
+//
+//----------------------------------------------------------------[ REPLAYER ]--
+
+// ReadSignals reads all signals from a record file.
+//
+func ReadSignals(r io.Reader) (list []RecordedSignal, e error) {
+	dec := json.NewDecoder(r)
+	for {
+		var rs RecordedSignal
+		e = dec.Decode(&rs)
+		if e == io.EOF {
+			return list, nil
+		}
+		if e != nil {
+			return list, e
+		}
+		list = append(list, rs)
+	}
+}
+
+// Replay reads signals from a record file and dispatches them to the client
+// registered objects, through its middlewares. See Replay for speed.
+//
+// Use a client created with NewReplayClient to test objects without server.
+//
+func (pulse *Client) Replay(ctx context.Context, r io.Reader, speed float64) error {
+	return Replay(ctx, r, pulse.DispatchSignal, speed)
+}
+
+// Replay reads signals from a record file and sends them to dispatch.
+//
+// speed sets the delay between signals, relative to the recorded delays:
+// ReplayInstant dispatches all signals without delay, ReplayRealTime with the
+// recorded delays, and 10 times faster.
+//
+// Replay returns at the end of the file, on a decoding error, or with the
+// context error if it's canceled.
+//
+func Replay(ctx context.Context, r io.Reader, dispatch Dispatcher, speed float64) error {
+	dec := json.NewDecoder(r)
+	var last time.Time
+	for {
+		var rs RecordedSignal
+		e := dec.Decode(&rs)
+		if e == io.EOF {
+			return nil
+		}
+		if e != nil {
+			return e
+		}
+
+		if speed > 0 && !last.IsZero() && rs.Time.After(last) {
+			timer := time.NewTimer(time.Duration(float64(rs.Time.Sub(last)) / speed))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		if e := ctx.Err(); e != nil {
+			return e
+		}
+
+		last = rs.Time
+		dispatch(rs.Signal())
+	}
+}
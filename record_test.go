@@ -0,0 +1,211 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecordReplay(t *testing.T) {
+	signals := []*dbus.Signal{
+		{Name: pulseaudio.DbusInterface + ".Device.VolumeUpdated", Path: "/sink0", Body: []interface{}{[]uint32{42000, 21000}}},
+		{Name: pulseaudio.DbusInterface + ".Device.MuteUpdated", Path: "/sink0", Body: []interface{}{true}},
+		{Name: pulseaudio.DbusInterface + ".SinkRemoved", Path: pulseaudio.DbusPath, Body: []interface{}{dbus.ObjectPath("/sink1")}},
+		{Name: pulseaudio.DbusInterface + ".Stream.PropertyListUpdated", Path: "/stream0", Body: []interface{}{map[string][]byte{"media.name": []byte("song\x00")}}},
+		{Name: "org.PulseAudio.Ext.Test", Path: "/test", Body: []interface{}{
+			[]interface{}{uint32(1), "two", []dbus.ObjectPath{"/three"}},
+			[][]interface{}{{uint32(0), uint32(42000)}, {uint32(1), uint32(21000)}},
+			map[string]dbus.Variant{"four": dbus.MakeVariant(map[uint32]float64{5: 0.5})},
+		}},
+		{Name: "org.PulseAudio.Ext.Empty", Path: "/test", Body: []interface{}{
+			[]uint32{},
+			[][]interface{}{},
+			map[string][]interface{}{},
+		}},
+	}
+
+	var buf bytes.Buffer
+	rec := pulseaudio.NewRecorder(&buf)
+	rec.OnError = func(e error) { t.Error("record:", e) }
+	dispatch := rec.Middleware(func(*dbus.Signal) {})
+	for _, s := range signals {
+		dispatch(s)
+	}
+
+	list, e := pulseaudio.ReadSignals(bytes.NewReader(buf.Bytes()))
+	if e != nil {
+		t.Fatal("read:", e)
+	}
+	if len(list) != len(signals) {
+		t.Fatalf("read: got %d signals, want %d", len(list), len(signals))
+	}
+	for i, rs := range list {
+		if got := rs.Signal(); !reflect.DeepEqual(got, signals[i]) {
+			t.Errorf("signal %d: got %+v, want %+v", i, got, signals[i])
+		}
+	}
+
+	pulse := pulseaudio.NewReplayClient()
+	pulse.SetOnUnknownSignal(func(*dbus.Signal) {})
+	obj := &volumeRecorder{volumes: make(map[dbus.ObjectPath][][]uint32)}
+	if errs := pulse.Register(obj); len(errs) > 0 {
+		t.Fatal("register:", errs)
+	}
+	e = pulse.Replay(context.Background(), &buf, pulseaudio.ReplayInstant)
+	if e != nil {
+		t.Fatal("replay:", e)
+	}
+	if want := map[dbus.ObjectPath][][]uint32{"/sink0": {{42000, 21000}}}; !reflect.DeepEqual(obj.volumes, want) {
+		t.Errorf("volumes: got %v, want %v", obj.volumes, want)
+	}
+	if want := []dbus.ObjectPath{"/sink1"}; !reflect.DeepEqual(obj.removed, want) {
+		t.Errorf("removed: got %v, want %v", obj.removed, want)
+	}
+}
+
+func TestReadSignalsInvalid(t *testing.T) {
+	for _, sig := range []string{"", "(", ")", "a", "a{", "a{}", "a{s", "a{su", "(u", "uu", "z"} {
+		data := `{"name":"org.PulseAudio.Ext.Test","path":"/test","body":[{"type":"` + sig + `","value":[]}]}`
+		_, e := pulseaudio.ReadSignals(strings.NewReader(data))
+		if e == nil {
+			t.Errorf("signature %q: no error", sig)
+		}
+	}
+}
+
+func TestReplaySpeed(t *testing.T) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	start := time.Date(2018, 11, 20, 21, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		enc.Encode(pulseaudio.RecordedSignal{Time: start.Add(time.Duration(i) * 200 * time.Millisecond), Name: "test"})
+	}
+	record := buf.Bytes()
+
+	count := 0
+	dispatch := func(*dbus.Signal) { count++ }
+
+	begin := time.Now()
+	e := pulseaudio.Replay(context.Background(), bytes.NewReader(record), dispatch, 10)
+	if elapsed := time.Since(begin); e != nil || count != 3 || elapsed < 40*time.Millisecond || elapsed > 300*time.Millisecond {
+		t.Errorf("speed 10: got %d signals in %s (error %v), want 3 in about 40ms", count, elapsed, e)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	count = 0
+	e = pulseaudio.Replay(ctx, bytes.NewReader(record), dispatch, pulseaudio.ReplayRealTime)
+	if e != context.DeadlineExceeded || count != 1 {
+		t.Errorf("canceled: got %d signals (error %v), want 1 and %v", count, e, context.DeadlineExceeded)
+	}
+}
+
+// fakeServer answers the objects calls of a replay client with a properties
+// store, and records the other calls.
+type fakeServer struct {
+	mu      sync.Mutex
+	props   map[dbus.ObjectPath]map[string]interface{} // Properties by object and name.
+	methods map[string]pulseaudio.ReplayHandler        // Method replies, by method name without interface.
+	calls   []string                                   // Methods called and properties set.
+}
+
+func newFakeServer(pulse *pulseaudio.Client) *fakeServer {
+	fs := &fakeServer{
+		props:   make(map[dbus.ObjectPath]map[string]interface{}),
+		methods: make(map[string]pulseaudio.ReplayHandler),
+	}
+	pulse.SetReplayHandler(fs.handle)
+	return fs
+}
+
+func (fs *fakeServer) set(path dbus.ObjectPath, name string, value interface{}) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.props[path] == nil {
+		fs.props[path] = make(map[string]interface{})
+	}
+	fs.props[path][name] = value
+}
+
+func (fs *fakeServer) get(path dbus.ObjectPath, name string) interface{} {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.props[path][name]
+}
+
+// takeCalls returns the calls recorded since the last call.
+func (fs *fakeServer) takeCalls() []string {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	calls := fs.calls
+	fs.calls = nil
+	return calls
+}
+
+func (fs *fakeServer) handle(path dbus.ObjectPath, method string, args ...interface{}) ([]interface{}, error) {
+	fs.mu.Lock()
+	switch method {
+	case "org.freedesktop.DBus.Properties.Get":
+		value, ok := fs.props[path][args[1].(string)]
+		fs.mu.Unlock()
+		if !ok {
+			return nil, dbus.Error{Name: "org.PulseAudio.Core1.NoSuchPropertyError", Body: []interface{}{"no property " + args[1].(string)}}
+		}
+		return []interface{}{dbus.MakeVariant(value)}, nil
+
+	case "org.freedesktop.DBus.Properties.Set":
+		name, value := args[1].(string), args[2].(dbus.Variant).Value()
+		if fs.props[path] == nil {
+			fs.props[path] = make(map[string]interface{})
+		}
+		fs.props[path][name] = value
+		fs.calls = append(fs.calls, fmt.Sprintf("%s Set %s %v", path, name, value))
+		fs.mu.Unlock()
+		return nil, nil
+	}
+
+	short := method[strings.LastIndex(method, ".")+1:]
+	fs.calls = append(fs.calls, fmt.Sprint(path, " ", short, args))
+	reply := fs.methods[short]
+	fs.mu.Unlock()
+	if reply != nil {
+		return reply(path, method, args...)
+	}
+	return nil, nil
+}
+
+func TestReplayHandler(t *testing.T) {
+	pulse := pulseaudio.NewReplayClient()
+	if _, e := pulse.TypedDevice("/sink0").Name(); e != pulseaudio.ErrNoServer {
+		t.Errorf("without handler: got %v, want %v", e, pulseaudio.ErrNoServer)
+	}
+
+	fs := newFakeServer(pulse)
+	fs.set("/sink0", "Name", "speakers")
+	dev := pulse.TypedDevice("/sink0")
+	if name, e := dev.Name(); e != nil || name != "speakers" {
+		t.Errorf("name: got %q (error %v), want speakers", name, e)
+	}
+	if _, e := dev.Bool("Mute"); e == nil {
+		t.Error("mute: want a missing property error")
+	}
+	if e := dev.Set("Mute", true); e != nil || fs.get("/sink0", "Mute") != true {
+		t.Errorf("set mute: got %v (error %v), want true", fs.get("/sink0", "Mute"), e)
+	}
+	if e := dev.Suspend(); e != nil {
+		t.Error("suspend:", e)
+	}
+	if calls, want := fs.takeCalls(), []string{"/sink0 Set Mute true", "/sink0 Suspend[true]"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls: got %v, want %v", calls, want)
+	}
+}
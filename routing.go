@@ -0,0 +1,262 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"path"
+	"sync"
+)
+
+// RouteRule selects devices for the Router policy. Empty fields match any
+// device, set fields must all match.
+//
+type RouteRule struct {
+	Name       string // Device name pattern, see path.Match (ex: "bluez_sink.*").
+	Bus        string // device.bus property (ex: usb, bluetooth, pci).
+	FormFactor string // device.form_factor property (ex: headphone, headset, speaker).
+	Port       string // Active port name pattern (ex: "analog-output-headphones").
+	Priority   int    // The device matching the rule with the highest priority is selected.
+}
+
+// Match returns whether the device matches the rule.
+//
+func (rule RouteRule) Match(dev RouteDevice) bool {
+	return matchPattern(rule.Name, dev.Name) &&
+		(rule.Bus == "" || rule.Bus == dev.Bus) &&
+		(rule.FormFactor == "" || rule.FormFactor == dev.FormFactor) &&
+		matchPattern(rule.Port, dev.Port)
+}
+
+// RouteDevice describes a device for the route selection.
+//
+type RouteDevice struct {
+	Path          dbus.ObjectPath
+	Name          string
+	Bus           string           // device.bus property.
+	FormFactor    string           // device.form_factor property.
+	Port          string           // Active port name, if any.
+	PortAvailable PortAvailability // Active port availability, PortAvailableUnknown without port.
+	Monitor       bool             // Source monitoring a sink.
+}
+
+// RouteDecision is a fallback device change selected by the Router policy.
+//
+type RouteDecision struct {
+	Source bool            // The decision is for the fallback source, not the sink.
+	From   dbus.ObjectPath // Current fallback device, empty if unset.
+	To     RouteDevice     // Selected device.
+	Rule   RouteRule       // Rule matched by the selected device.
+	Reason string          // Event that triggered the decision (ex: NewSink, Start).
+}
+
+// SelectRoute returns the device matching a rule with the highest priority.
+// Monitor sources and devices with an unplugged active port are ignored.
+// When devices have the same priority, the current device is kept, otherwise
+// the first one is selected.
+//
+func SelectRoute(rules []RouteRule, devices []RouteDevice, current dbus.ObjectPath) (dev RouteDevice, rule RouteRule, found bool) {
+	for _, test := range devices {
+		if test.Monitor || test.PortAvailable == PortAvailableNo {
+			continue
+		}
+		for _, r := range rules {
+			if !r.Match(test) {
+				continue
+			}
+			if !found || r.Priority > rule.Priority || (r.Priority == rule.Priority && test.Path == current && dev.Path != current) {
+				dev, rule, found = test, r, true
+			}
+		}
+	}
+	return dev, rule, found
+}
+
+// Router is an opt-in policy that selects the fallback sink and source with
+// priority rules, and moves the streams to the selected devices.
+//
+// Routes are evaluated when a device is added or removed, when the active
+// port of a device changes (ex: headphones plugged when the server loads
+// module-switch-on-port-available), and when the availability of a port
+// changes (ex: headphones unplugged from the active port).
+//
+//   router := pulseaudio.NewRouter(pulse,
+//   	pulseaudio.RouteRule{Bus: "bluetooth", Priority: 30},
+//   	pulseaudio.RouteRule{Bus: "usb", Priority: 20},
+//   	pulseaudio.RouteRule{Port: "*headphones*", Priority: 10},
+//   	pulseaudio.RouteRule{}, // Any other device.
+//   )
+//   router.Decide = func(d pulseaudio.RouteDecision) bool { return !d.Source }
+//   router.Start()
+//   defer router.Stop()
+//
+type Router struct {
+	Rules       []RouteRule
+	MoveStreams bool                     // Move all streams to the new device. Default true.
+	Decide      func(RouteDecision) bool // Veto callback, return false to cancel a change. Optional.
+	OnRoute     func(RouteDecision)      // Called after a change is applied. Optional.
+	OnError     func(error)              // Error logger callback. Optional.
+
+	pulse *Client
+	mu    sync.Mutex // Serializes the evaluations.
+}
+
+// NewRouter creates a routing policy with the given rules.
+//
+func NewRouter(pulse *Client, rules ...RouteRule) *Router {
+	return &Router{
+		Rules:       rules,
+		MoveStreams: true,
+		pulse:       pulse,
+	}
+}
+
+// Start registers the policy to the client events and applies the routes to
+// the current devices.
+//
+func (r *Router) Start() (errs []error) {
+	errs = r.pulse.Register(r)
+	r.Evaluate("Start")
+	return errs
+}
+
+// Stop unregisters the policy.
+//
+func (r *Router) Stop() (errs []error) {
+	return r.pulse.Unregister(r)
+}
+
+// Evaluate applies the routes for sinks and sources. Reason is forwarded to
+// the decision callbacks.
+//
+func (r *Router) Evaluate(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logError(r.route(false, reason))
+	r.logError(r.route(true, reason))
+}
+
+// NewSink is called when a sink is added.
+//
+func (r *Router) NewSink(path dbus.ObjectPath) { r.Evaluate("NewSink") }
+
+// SinkRemoved is called when a sink is removed.
+//
+func (r *Router) SinkRemoved(path dbus.ObjectPath) { r.Evaluate("SinkRemoved") }
+
+// NewSource is called when a source is added.
+//
+func (r *Router) NewSource(path dbus.ObjectPath) { r.Evaluate("NewSource") }
+
+// SourceRemoved is called when a source is removed.
+//
+func (r *Router) SourceRemoved(path dbus.ObjectPath) { r.Evaluate("SourceRemoved") }
+
+// DeviceActivePortUpdated is called when the port has changed on a device.
+//
+func (r *Router) DeviceActivePortUpdated(path, port dbus.ObjectPath) {
+	r.Evaluate("DeviceActivePortUpdated")
+}
+
+// DevicePortAvailableChanged is called when a port is plugged or unplugged.
+//
+func (r *Router) DevicePortAvailableChanged(path dbus.ObjectPath, available PortAvailability) {
+	r.Evaluate("DevicePortAvailableChanged")
+}
+
+// route selects and applies the fallback device of one type. Must be locked.
+//
+func (r *Router) route(source bool, reason string) error {
+	list, fallback, streams := "Sinks", "FallbackSink", "PlaybackStreams"
+	if source {
+		list, fallback, streams = "Sources", "FallbackSource", "RecordStreams"
+	}
+
+	core := r.pulse.Core()
+	paths, e := core.ListPath(list)
+	if e != nil {
+		return e
+	}
+	devices := make([]RouteDevice, 0, len(paths))
+	for _, path := range paths {
+		dev, e := r.describe(path)
+		if e != nil {
+			r.logError(e) // The device may have been removed meanwhile.
+			continue
+		}
+		devices = append(devices, dev)
+	}
+
+	current, _ := core.ObjectPath(fallback) // Unset fallback returns an error.
+	dev, rule, found := SelectRoute(r.Rules, devices, current)
+	if !found || dev.Path == current {
+		return nil
+	}
+
+	decision := RouteDecision{Source: source, From: current, To: dev, Rule: rule, Reason: reason}
+	if r.Decide != nil && !r.Decide(decision) {
+		return nil
+	}
+	if e = core.Set(fallback, dev.Path); e != nil {
+		return e
+	}
+
+	if r.MoveStreams {
+		list, e := core.ListPath(streams)
+		if e != nil {
+			return e
+		}
+		for _, path := range list {
+			stream := r.pulse.TypedStream(path)
+			if on, e := stream.Device(); e == nil && on != dev.Path {
+				r.logError(stream.Move(dev.Path))
+			}
+		}
+	}
+
+	if r.OnRoute != nil {
+		r.OnRoute(decision)
+	}
+	return nil
+}
+
+// describe queries the route informations of a device.
+//
+func (r *Router) describe(path dbus.ObjectPath) (RouteDevice, error) {
+	dev := r.pulse.TypedDevice(path)
+	name, e := dev.Name()
+	if e != nil {
+		return RouteDevice{}, e
+	}
+	props, _ := dev.PropertyList()
+	info := RouteDevice{
+		Path:       path,
+		Name:       name,
+		Bus:        props.DeviceBus(),
+		FormFactor: props.DeviceFormFactor(),
+		Monitor:    props.DeviceClass() == "monitor",
+	}
+	if port, e := dev.ActivePort(); e == nil { // Devices without ports return an error.
+		info.Port, _ = port.Name()
+		info.PortAvailable, _ = port.Available()
+	}
+	return info, nil
+}
+
+// logError forwards an error to the OnError callback if any.
+//
+func (r *Router) logError(e error) {
+	if e != nil && r.OnError != nil {
+		r.OnError(e)
+	}
+}
+
+// matchPattern returns whether the value matches the pattern, see path.Match.
+// An empty pattern matches everything.
+//
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	match, _ := path.Match(pattern, value)
+	return match
+}
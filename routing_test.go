@@ -0,0 +1,71 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSelectRoute(t *testing.T) {
+	speakers := pulseaudio.RouteDevice{Path: "/sink0", Name: "alsa_output.pci.analog-stereo", Bus: "pci", Port: "analog-output-speaker"}
+	headphones := pulseaudio.RouteDevice{Path: "/sink0", Name: "alsa_output.pci.analog-stereo", Bus: "pci", Port: "analog-output-headphones", PortAvailable: pulseaudio.PortAvailableYes}
+	unplugged := pulseaudio.RouteDevice{Path: "/sink1", Name: "alsa_output.pci.hdmi", Bus: "pci", Port: "hdmi-output-0", PortAvailable: pulseaudio.PortAvailableNo}
+	usb := pulseaudio.RouteDevice{Path: "/sink2", Name: "alsa_output.usb-headset", Bus: "usb", FormFactor: "headset"}
+	bt := pulseaudio.RouteDevice{Path: "/sink3", Name: "bluez_sink.00_11", Bus: "bluetooth"}
+	monitor := pulseaudio.RouteDevice{Path: "/source0", Name: "alsa_output.usb-headset.monitor", Bus: "usb", Monitor: true}
+
+	rules := []pulseaudio.RouteRule{
+		{Name: "bluez_sink.*", Priority: 30},
+		{Bus: "usb", FormFactor: "headset", Priority: 20},
+		{Port: "*headphones*", Priority: 10},
+		{}, // Any device.
+	}
+
+	for _, test := range []struct {
+		name    string
+		devices []pulseaudio.RouteDevice
+		current string
+		want    string
+		found   bool
+	}{
+		{"bluetooth first", []pulseaudio.RouteDevice{speakers, usb, bt}, "/sink0", "/sink3", true},
+		{"usb over headphones", []pulseaudio.RouteDevice{headphones, usb}, "/sink0", "/sink2", true},
+		{"keep current on tie", []pulseaudio.RouteDevice{speakers, unplugged, {Path: "/sink4", Name: "other"}}, "/sink4", "/sink4", true},
+		{"skip unplugged and monitor", []pulseaudio.RouteDevice{unplugged, monitor}, "", "", false},
+		{"empty", nil, "/sink0", "", false},
+	} {
+		dev, _, found := pulseaudio.SelectRoute(rules, test.devices, dbus.ObjectPath(test.current))
+		if found != test.found || string(dev.Path) != test.want {
+			t.Errorf("%s: got %q, %v, want %q, %v", test.name, dev.Path, found, test.want, test.found)
+		}
+	}
+
+	if _, _, found := pulseaudio.SelectRoute(rules[:1], []pulseaudio.RouteDevice{speakers}, ""); found {
+		t.Error("no matching rule: expected no device")
+	}
+}
+
+func TestRouterPortAvailable(t *testing.T) {
+	pulse := pulseaudio.NewReplayClient()
+	router := pulseaudio.NewRouter(pulse, pulseaudio.RouteRule{})
+	var errs []error
+	router.OnError = func(e error) { errs = append(errs, e) }
+	if regErrs := pulse.Register(router); len(regErrs) > 0 {
+		t.Fatal("register:", regErrs)
+	}
+
+	// Without server, each evaluation fails to list the sinks and the sources.
+	const record = `{"time":"2018-11-20T21:10:52.39+01:00","name":"org.PulseAudio.Core1.DevicePort.AvailableChanged",` +
+		`"path":"/org/pulseaudio/core1/card0/port0","body":[{"type":"u","value":1}]}`
+	e := pulse.Replay(context.Background(), strings.NewReader(record), pulseaudio.ReplayInstant)
+	if e != nil {
+		t.Fatal("replay:", e)
+	}
+	if len(errs) != 2 || errs[0] != pulseaudio.ErrNoServer {
+		t.Errorf("port availability changed: got evaluation errors %v, want 2 ErrNoServer", errs)
+	}
+}
@@ -0,0 +1,383 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+// SampleFormat defines the encoding of audio samples.
+//
+type SampleFormat uint32
+
+// Sample formats, as defined by pulseaudio.
+//
+const (
+	SampleU8        SampleFormat = iota // Unsigned 8 bit PCM.
+	SampleALaw                          // 8 bit a-Law.
+	SampleULaw                          // 8 bit mu-Law.
+	SampleS16LE                         // Signed 16 bit PCM, little endian.
+	SampleS16BE                         // Signed 16 bit PCM, big endian.
+	SampleFloat32LE                     // 32 bit IEEE floating point, little endian, range -1.0 to 1.0.
+	SampleFloat32BE                     // 32 bit IEEE floating point, big endian, range -1.0 to 1.0.
+	SampleS32LE                         // Signed 32 bit PCM, little endian.
+	SampleS32BE                         // Signed 32 bit PCM, big endian.
+	SampleS24LE                         // Signed 24 bit PCM packed, little endian.
+	SampleS24BE                         // Signed 24 bit PCM packed, big endian.
+	SampleS24In32LE                     // Signed 24 bit PCM in LSB of 32 bit words, little endian.
+	SampleS24In32BE                     // Signed 24 bit PCM in LSB of 32 bit words, big endian.
+)
+
+// Size returns the size in bytes of one sample of the format.
+//
+func (f SampleFormat) Size() int {
+	switch f {
+	case SampleU8, SampleALaw, SampleULaw:
+		return 1
+	case SampleS16LE, SampleS16BE:
+		return 2
+	case SampleS24LE, SampleS24BE:
+		return 3
+	}
+	return 4
+}
+
+// Channel positions, as defined by pulseaudio.
+//
+const (
+	ChannelMono uint32 = iota
+	ChannelFrontLeft
+	ChannelFrontRight
+	ChannelFrontCenter
+	ChannelRearCenter
+	ChannelRearLeft
+	ChannelRearRight
+	ChannelLFE
+	ChannelFrontLeftOfCenter
+	ChannelFrontRightOfCenter
+	ChannelSideLeft
+	ChannelSideRight
+)
+
+// SampleSpec describes the format of audio data.
+//
+type SampleSpec struct {
+	Format   SampleFormat
+	Rate     uint32   // Samples per second.
+	Channels []uint32 // Channel map, one position per channel.
+}
+
+// Duration returns the playing time of size bytes of data in this format.
+//
+func (spec SampleSpec) Duration(size int) time.Duration {
+	frame := spec.Format.Size() * len(spec.Channels)
+	if frame == 0 || spec.Rate == 0 {
+		return 0
+	}
+	return time.Duration(size/frame) * time.Second / time.Duration(spec.Rate)
+}
+
+//
+//------------------------------------------------------------------[ SAMPLE ]--
+
+// Sample is a typed access to a pulseaudio sample cache entry.
+//
+// Methods list:
+//   Play         Plays the sample on the fallback sink.
+//     []uint32:    Volume, empty for the default volume.
+//     PropertyList: Properties of the playback stream.
+//   PlayToSink   Plays the sample on the given sink.
+//     ObjectPath:  Sink to play on.
+//     []uint32:    Volume, empty for the default volume.
+//     PropertyList: Properties of the playback stream.
+//   Remove       Removes the sample from the cache.
+//
+// Properties list:
+//   Uint32
+//     Index          The sample index.
+//     !SampleFormat  The sample format.
+//     !SampleRate    The sample rate.
+//     Bytes          The size of the sample data, in bytes.
+//
+//   Uint64
+//     Duration       The duration of the sample, in microseconds.
+//
+//   String
+//     Name           The sample name.
+//
+//   ListUint32
+//     Channels       The channel map of the sample.
+//     !DefaultVolume The default volume of the sample. Empty if no default volume.
+//
+//   MapString
+//     !PropertyList  The sample's property list.
+//
+type Sample struct {
+	*Object
+}
+
+// Sample returns a typed access to a sample of the cache.
+//
+func (pulse *Client) Sample(path dbus.ObjectPath) *Sample {
+	return &Sample{pulse.newObject(DbusInterface+".Sample", path)}
+}
+
+// Samples returns all samples currently loaded in the cache.
+//
+func (pulse *Client) Samples() ([]*Sample, error) {
+	paths, e := pulse.Core().ListPath("Samples")
+	if e != nil {
+		return nil, e
+	}
+	samples := make([]*Sample, len(paths))
+	for i, path := range paths {
+		samples[i] = pulse.Sample(path)
+	}
+	return samples, nil
+}
+
+// SampleByName finds a sample of the cache by its name.
+//
+func (pulse *Client) SampleByName(name string) (*Sample, error) {
+	var path dbus.ObjectPath
+	e := pulse.Core().Call(DbusInterface+".GetSampleByName", 0, name).Store(&path)
+	if e != nil {
+		return nil, e
+	}
+	return pulse.Sample(path), nil
+}
+
+// UploadSample adds a sample to the cache.
+// volume is the default volume of the sample, and can be empty.
+// data must match the format given in spec.
+//
+func (pulse *Client) UploadSample(name string, spec SampleSpec, volume []uint32, props PropertyList, data []byte) (*Sample, error) {
+	if volume == nil {
+		volume = []uint32{}
+	}
+	if props == nil {
+		props = PropertyList{}
+	}
+	var path dbus.ObjectPath
+	e := pulse.Core().Call(DbusInterface+".UploadSample", 0,
+		name, spec.Format, spec.Rate, spec.Channels, volume, props, data,
+	).Store(&path)
+	if e != nil {
+		return nil, e
+	}
+	return pulse.Sample(path), nil
+}
+
+// UploadWAV adds a sample to the cache from WAV encoded data.
+// See UploadSample.
+//
+func (pulse *Client) UploadWAV(name string, r io.Reader, volume []uint32, props PropertyList) (*Sample, error) {
+	spec, data, e := DecodeWAV(r)
+	if e != nil {
+		return nil, e
+	}
+	return pulse.UploadSample(name, spec, volume, props, data)
+}
+
+// Name returns the sample name.
+//
+func (sample *Sample) Name() (string, error) {
+	return sample.String("Name")
+}
+
+// Index returns the sample index.
+//
+func (sample *Sample) Index() (uint32, error) {
+	return sample.Uint32("Index")
+}
+
+// Duration returns the sample duration.
+//
+func (sample *Sample) Duration() (time.Duration, error) {
+	usec, e := sample.Uint64("Duration")
+	return time.Duration(usec) * time.Microsecond, e
+}
+
+// Bytes returns the size of the sample data, in bytes.
+//
+func (sample *Sample) Bytes() (uint32, error) {
+	return sample.Uint32("Bytes")
+}
+
+// Volume returns the default volume of the sample. Empty if not set.
+//
+func (sample *Sample) Volume() ([]uint32, error) {
+	return sample.ListUint32("DefaultVolume")
+}
+
+// PropertyList returns the sample property list.
+//
+func (sample *Sample) PropertyList() (PropertyList, error) {
+	return sample.Object.PropertyList("PropertyList")
+}
+
+// Play plays the sample on the fallback sink.
+// volume and props are optional.
+//
+func (sample *Sample) Play(volume []uint32, props PropertyList) error {
+	if volume == nil {
+		volume = []uint32{}
+	}
+	if props == nil {
+		props = PropertyList{}
+	}
+	return sample.Call(sample.prefix+".Play", 0, volume, props).Err
+}
+
+// PlayToSink plays the sample on the given sink.
+// volume and props are optional.
+//
+func (sample *Sample) PlayToSink(sink dbus.ObjectPath, volume []uint32, props PropertyList) error {
+	if volume == nil {
+		volume = []uint32{}
+	}
+	if props == nil {
+		props = PropertyList{}
+	}
+	return sample.Call(sample.prefix+".PlayToSink", 0, sink, volume, props).Err
+}
+
+// Remove removes the sample from the cache.
+//
+func (sample *Sample) Remove() error {
+	return sample.Call(sample.prefix+".Remove", 0).Err
+}
+
+//
+//-------------------------------------------------------------[ WAV DECODER ]--
+
+// WAV format tags.
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatALaw       = 6
+	wavFormatULaw       = 7
+	wavFormatExtensible = 0xFFFE
+)
+
+// wavChannels is the default WAV channel order.
+var wavChannels = []uint32{
+	ChannelFrontLeft, ChannelFrontRight, ChannelFrontCenter, ChannelLFE,
+	ChannelRearLeft, ChannelRearRight, ChannelFrontLeftOfCenter,
+	ChannelFrontRightOfCenter, ChannelRearCenter, ChannelSideLeft, ChannelSideRight,
+}
+
+// ErrInvalidWAV is returned when the data to decode isn't in a supported WAV format.
+//
+var ErrInvalidWAV = errors.New("invalid WAV data")
+
+// DecodeWAV reads WAV encoded data and returns its format and raw data, ready
+// to be uploaded with UploadSample.
+//
+// Supported encodings are PCM (8, 16, 24 and 32 bits), IEEE float (32 bits),
+// a-Law and mu-Law.
+//
+func DecodeWAV(r io.Reader) (spec SampleSpec, data []byte, e error) {
+	buf, e := ioutil.ReadAll(r)
+	if e != nil {
+		return spec, nil, e
+	}
+	if len(buf) < 12 || string(buf[:4]) != "RIFF" || string(buf[8:12]) != "WAVE" {
+		return spec, nil, ErrInvalidWAV
+	}
+
+	var (
+		hasFormat bool
+		tag       uint16
+		channels  uint16
+		bits      uint16
+	)
+	for buf = buf[12:]; len(buf) >= 8; {
+		id := string(buf[:4])
+		size := int(binary.LittleEndian.Uint32(buf[4:8]))
+		buf = buf[8:]
+		if size > len(buf) {
+			if id != "data" {
+				return spec, nil, ErrInvalidWAV
+			}
+			size = len(buf) // Truncated or streamed file, keep what we have.
+		}
+		chunk := buf[:size]
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return spec, nil, ErrInvalidWAV
+			}
+			tag = binary.LittleEndian.Uint16(chunk[0:2])
+			channels = binary.LittleEndian.Uint16(chunk[2:4])
+			spec.Rate = binary.LittleEndian.Uint32(chunk[4:8])
+			bits = binary.LittleEndian.Uint16(chunk[14:16])
+			if tag == wavFormatExtensible && size >= 26 {
+				tag = binary.LittleEndian.Uint16(chunk[24:26]) // Sub format GUID first bytes.
+			}
+			hasFormat = true
+
+		case "data":
+			if !hasFormat {
+				return spec, nil, ErrInvalidWAV
+			}
+			spec.Format, e = wavSampleFormat(tag, bits)
+			if e != nil {
+				return spec, nil, e
+			}
+			spec.Channels, e = wavChannelMap(int(channels))
+			if e != nil {
+				return spec, nil, e
+			}
+			frame := spec.Format.Size() * len(spec.Channels)
+			return spec, chunk[:size-size%frame], nil
+		}
+
+		size += size % 2 // Chunks are word aligned.
+		if size > len(buf) {
+			break
+		}
+		buf = buf[size:]
+	}
+	return spec, nil, ErrInvalidWAV
+}
+
+// wavSampleFormat returns the sample format matching a WAV format tag.
+//
+func wavSampleFormat(tag, bits uint16) (SampleFormat, error) {
+	switch {
+	case tag == wavFormatPCM && bits == 8:
+		return SampleU8, nil
+	case tag == wavFormatPCM && bits == 16:
+		return SampleS16LE, nil
+	case tag == wavFormatPCM && bits == 24:
+		return SampleS24LE, nil
+	case tag == wavFormatPCM && bits == 32:
+		return SampleS32LE, nil
+	case tag == wavFormatFloat && bits == 32:
+		return SampleFloat32LE, nil
+	case tag == wavFormatALaw && bits == 8:
+		return SampleALaw, nil
+	case tag == wavFormatULaw && bits == 8:
+		return SampleULaw, nil
+	}
+	return 0, fmt.Errorf("%w: unsupported format %d with %d bits", ErrInvalidWAV, tag, bits)
+}
+
+// wavChannelMap returns the default channel map for a number of channels.
+//
+func wavChannelMap(count int) ([]uint32, error) {
+	switch {
+	case count == 1:
+		return []uint32{ChannelMono}, nil
+	case count > 1 && count <= len(wavChannels):
+		return append([]uint32{}, wavChannels[:count]...), nil
+	}
+	return nil, fmt.Errorf("%w: unsupported channels count %d", ErrInvalidWAV, count)
+}
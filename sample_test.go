@@ -0,0 +1,67 @@
+package pulseaudio_test
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeWAV(t *testing.T) {
+	pcm := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	spec, data, e := pulseaudio.DecodeWAV(bytes.NewReader(makeWAV(1, 2, 44100, 16, pcm)))
+	if e != nil {
+		t.Fatal("decode WAV:", e)
+	}
+	want := pulseaudio.SampleSpec{
+		Format:   pulseaudio.SampleS16LE,
+		Rate:     44100,
+		Channels: []uint32{pulseaudio.ChannelFrontLeft, pulseaudio.ChannelFrontRight},
+	}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("decode WAV spec: got %+v, want %+v", spec, want)
+	}
+	if !bytes.Equal(data, pcm) {
+		t.Errorf("decode WAV data: got %v, want %v", data, pcm)
+	}
+	if d := spec.Duration(44100 * 4); d != time.Second {
+		t.Errorf("spec duration: got %s, want 1s", d)
+	}
+
+	spec, _, e = pulseaudio.DecodeWAV(bytes.NewReader(makeWAV(3, 1, 8000, 32, pcm)))
+	if e != nil || spec.Format != pulseaudio.SampleFloat32LE || len(spec.Channels) != 1 {
+		t.Errorf("decode float WAV: got %+v, %v", spec, e)
+	}
+
+	for name, wav := range map[string][]byte{
+		"empty":       {},
+		"not riff":    []byte("RIFX\x00\x00\x00\x00WAVE"),
+		"no data":     makeWAV(1, 2, 44100, 16, nil)[:36],
+		"bad bits":    makeWAV(1, 2, 44100, 12, pcm),
+		"no channels": makeWAV(1, 0, 44100, 16, pcm),
+	} {
+		if _, _, e := pulseaudio.DecodeWAV(bytes.NewReader(wav)); !errors.Is(e, pulseaudio.ErrInvalidWAV) {
+			t.Errorf("decode WAV %s: got %v, want ErrInvalidWAV", name, e)
+		}
+	}
+}
+
+func makeWAV(tag, channels uint16, rate uint32, bits uint16, data []byte) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("RIFF")
+	binary.Write(buf, binary.LittleEndian, uint32(36+len(data)))
+	buf.WriteString("WAVEfmt ")
+	frame := channels * bits / 8
+	for _, v := range []interface{}{uint32(16), tag, channels, rate, rate * uint32(frame), frame, bits} {
+		binary.Write(buf, binary.LittleEndian, v)
+	}
+	buf.WriteString("data")
+	binary.Write(buf, binary.LittleEndian, uint32(len(data)))
+	buf.Write(data)
+	return buf.Bytes()
+}
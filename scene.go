@@ -0,0 +1,471 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"fmt"
+	"strings"
+)
+
+// SceneStreamKeys defines the properties used to identify streams in a scene,
+// as stream paths and indexes change with each run of an application.
+// Properties missing on a stream are ignored.
+//
+var SceneStreamKeys = []string{PropApplicationName, PropMediaRole}
+
+// Scene is a snapshot of the mixer setup: card profiles, device ports and
+// volumes, default devices and streams routing.
+//
+// Objects are identified by names and properties instead of object paths, so a
+// scene can be saved and applied later, after a restart of the server.
+// The struct is tagged for JSON and YAML encoding.
+//
+//   scene, e := pulse.CaptureScene()
+//   data, e := json.MarshalIndent(scene, "", "  ")
+//   ...
+//   plan, e := pulse.PlanScene(scene)
+//   fmt.Print(plan)
+//   errs := plan.Apply()
+//
+type Scene struct {
+	FallbackSink   string        `json:"fallback_sink,omitempty" yaml:"fallback_sink,omitempty"`
+	FallbackSource string        `json:"fallback_source,omitempty" yaml:"fallback_source,omitempty"`
+	Cards          []SceneCard   `json:"cards,omitempty" yaml:"cards,omitempty"`
+	Sinks          []SceneDevice `json:"sinks,omitempty" yaml:"sinks,omitempty"`
+	Sources        []SceneDevice `json:"sources,omitempty" yaml:"sources,omitempty"`
+	Streams        []SceneStream `json:"streams,omitempty" yaml:"streams,omitempty"`
+}
+
+// SceneCard is the state of a card in a scene.
+//
+type SceneCard struct {
+	Name    string `json:"name" yaml:"name"`
+	Profile string `json:"profile" yaml:"profile"`
+}
+
+// SceneDevice is the state of a sink or source in a scene.
+//
+type SceneDevice struct {
+	Name   string   `json:"name" yaml:"name"`
+	Port   string   `json:"port,omitempty" yaml:"port,omitempty"`
+	Volume []uint32 `json:"volume,omitempty" yaml:"volume,omitempty"`
+	Mute   bool     `json:"mute" yaml:"mute"`
+}
+
+// SceneStream is the state of streams in a scene. It applies to all streams
+// matching the properties.
+//
+type SceneStream struct {
+	Record bool              `json:"record,omitempty" yaml:"record,omitempty"` // Record stream, not playback.
+	Match  map[string]string `json:"match" yaml:"match"`                       // Properties identifying the streams.
+	Device string            `json:"device,omitempty" yaml:"device,omitempty"` // Device name.
+	Volume []uint32          `json:"volume,omitempty" yaml:"volume,omitempty"`
+	Mute   *bool             `json:"mute,omitempty" yaml:"mute,omitempty"` // Not set for record streams.
+}
+
+// Matches returns whether the stream properties match the scene stream.
+//
+func (ss SceneStream) Matches(props PropertyList) bool {
+	if len(ss.Match) == 0 {
+		return false
+	}
+	for key, value := range ss.Match {
+		if props.String(key) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// SceneChange is a change to apply to the server, as planned by PlanScene.
+//
+type SceneChange struct {
+	Object   string // Object description (ex: "sink alsa_output.pci-0000").
+	Property string // Changed property, or Move.
+	From     string // Current value, empty if unknown.
+	To       string // New value.
+
+	apply func() error
+}
+
+// String formats the change on one line.
+//
+func (change SceneChange) String() string {
+	from := change.From
+	if from == "" {
+		from = "?"
+	}
+	return fmt.Sprintf("%s: %s %s -> %s", change.Object, change.Property, from, change.To)
+}
+
+// Apply applies the change.
+//
+func (change SceneChange) Apply() error {
+	return change.apply()
+}
+
+// ScenePlan lists the changes needed to apply a scene.
+//
+type ScenePlan struct {
+	Changes []SceneChange
+	Missing []string // Scene objects not found on the server, skipped.
+}
+
+// String formats the plan, one change per line.
+//
+func (plan ScenePlan) String() string {
+	var lines []string
+	for _, change := range plan.Changes {
+		lines = append(lines, change.String()+"\n")
+	}
+	for _, missing := range plan.Missing {
+		lines = append(lines, missing+": missing, skipped\n")
+	}
+	return strings.Join(lines, "")
+}
+
+// Apply applies all changes in order. Errors don't stop the next changes.
+//
+func (plan ScenePlan) Apply() (errs []error) {
+	for _, change := range plan.Changes {
+		if e := change.Apply(); e != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", change.Object, e))
+		}
+	}
+	return errs
+}
+
+//
+//----------------------------------------------------------------[ CAPTURE ]--
+
+// CaptureScene returns the current mixer setup.
+//
+func (pulse *Client) CaptureScene() (*Scene, error) {
+	scene := &Scene{}
+	core := pulse.Core()
+
+	cards, e := pulse.Cards()
+	if e != nil {
+		return nil, e
+	}
+	for _, card := range cards {
+		name, e := card.Name()
+		if e != nil {
+			return nil, e
+		}
+		sc := SceneCard{Name: name}
+		if profile, e := card.ActiveProfile(); e == nil {
+			sc.Profile, _ = profile.Name()
+		}
+		scene.Cards = append(scene.Cards, sc)
+	}
+
+	if scene.Sinks, e = pulse.captureDevices("Sinks"); e != nil {
+		return nil, e
+	}
+	if scene.Sources, e = pulse.captureDevices("Sources"); e != nil {
+		return nil, e
+	}
+
+	// Fallback devices may be unset.
+	if path, e := core.ObjectPath("FallbackSink"); e == nil {
+		scene.FallbackSink, _ = pulse.TypedDevice(path).Name()
+	}
+	if path, e := core.ObjectPath("FallbackSource"); e == nil {
+		scene.FallbackSource, _ = pulse.TypedDevice(path).Name()
+	}
+
+	for _, list := range []string{"PlaybackStreams", "RecordStreams"} {
+		paths, e := core.ListPath(list)
+		if e != nil {
+			return nil, e
+		}
+		for _, path := range paths {
+			if ss, ok := pulse.captureStream(path, list == "RecordStreams"); ok {
+				scene.Streams = append(scene.Streams, ss)
+			}
+		}
+	}
+	return scene, nil
+}
+
+func (pulse *Client) captureDevices(list string) ([]SceneDevice, error) {
+	paths, e := pulse.Core().ListPath(list)
+	if e != nil {
+		return nil, e
+	}
+	var devices []SceneDevice
+	for _, path := range paths {
+		dev := pulse.TypedDevice(path)
+		var sd SceneDevice
+		if sd.Name, e = dev.Name(); e != nil {
+			return nil, e
+		}
+		if sd.Volume, e = dev.ListUint32("Volume"); e != nil {
+			return nil, e
+		}
+		if sd.Mute, e = dev.Bool("Mute"); e != nil {
+			return nil, e
+		}
+		if port, e := dev.ActivePort(); e == nil {
+			sd.Port, _ = port.Name()
+		}
+		devices = append(devices, sd)
+	}
+	return devices, nil
+}
+
+// captureStream returns the scene state of a stream. Streams without any of
+// the SceneStreamKeys properties are ignored.
+//
+func (pulse *Client) captureStream(path dbus.ObjectPath, record bool) (SceneStream, bool) {
+	stream := pulse.TypedStream(path)
+	props, e := stream.PropertyList()
+	if e != nil {
+		return SceneStream{}, false
+	}
+	ss := SceneStream{Record: record, Match: make(map[string]string)}
+	for _, key := range SceneStreamKeys {
+		if value := props.String(key); value != "" {
+			ss.Match[key] = value
+		}
+	}
+	if len(ss.Match) == 0 {
+		return SceneStream{}, false
+	}
+	if dev, e := stream.Device(); e == nil {
+		ss.Device, _ = pulse.TypedDevice(dev).Name()
+	}
+	ss.Volume, _ = stream.ListUint32("Volume")
+	if mute, e := stream.Bool("Mute"); e == nil {
+		ss.Mute = &mute
+	}
+	return ss, true
+}
+
+//
+//-------------------------------------------------------------------[ PLAN ]--
+
+// PlanScene compares a scene with the current setup and returns the changes
+// needed to apply it. Nothing is changed until the plan is applied.
+//
+// Card profile changes are applied first, as they can create and remove
+// devices. Devices missing when planning are then expected to be created by
+// the profile changes, their settings are planned without current values.
+//
+func (pulse *Client) PlanScene(scene *Scene) (plan ScenePlan, e error) {
+	for _, sc := range scene.Cards {
+		card, e := pulse.CardByName(sc.Name)
+		if e != nil {
+			plan.Missing = append(plan.Missing, "card "+sc.Name)
+			continue
+		}
+		current := ""
+		if profile, e := card.ActiveProfile(); e == nil {
+			current, _ = profile.Name()
+		}
+		if sc.Profile != "" && sc.Profile != current {
+			name, profile := sc.Name, sc.Profile
+			plan.add("card "+name, "ActiveProfile", current, profile, func() error {
+				card, e := pulse.CardByName(name)
+				if e != nil {
+					return e
+				}
+				found, e := card.ProfileByName(profile)
+				if e != nil {
+					return e
+				}
+				return card.SetActiveProfile(found.Path())
+			})
+		}
+	}
+	profileChanges := len(plan.Changes) > 0
+
+	pulse.planDevices(&plan, "sink", pulse.SinkByName, scene.Sinks, profileChanges)
+	pulse.planDevices(&plan, "source", pulse.SourceByName, scene.Sources, profileChanges)
+	pulse.planFallback(&plan, "FallbackSink", pulse.SinkByName, scene.FallbackSink, profileChanges)
+	pulse.planFallback(&plan, "FallbackSource", pulse.SourceByName, scene.FallbackSource, profileChanges)
+
+	if len(scene.Streams) > 0 {
+		if e = pulse.planStreams(&plan, scene.Streams); e != nil {
+			return plan, e
+		}
+	}
+	return plan, nil
+}
+
+func (plan *ScenePlan) add(object, property, from, to string, apply func() error) {
+	plan.Changes = append(plan.Changes, SceneChange{Object: object, Property: property, From: from, To: to, apply: apply})
+}
+
+func (pulse *Client) planDevices(plan *ScenePlan, kind string, byName func(string) (*Device, error), devices []SceneDevice, pending bool) {
+	for _, sd := range devices {
+		sd := sd
+		object := kind + " " + sd.Name
+		var curPort, curVolume, curMute string
+		dev, e := byName(sd.Name)
+		switch {
+		case e == nil:
+			if port, e := dev.ActivePort(); e == nil {
+				curPort, _ = port.Name()
+			}
+			vol, _ := dev.ListUint32("Volume")
+			curVolume = fmt.Sprint(vol)
+			mute, _ := dev.Bool("Mute")
+			curMute = fmt.Sprint(mute)
+
+		case !pending:
+			plan.Missing = append(plan.Missing, object)
+			continue
+		}
+
+		if sd.Port != "" && sd.Port != curPort {
+			plan.add(object, "ActivePort", curPort, sd.Port, func() error {
+				dev, e := byName(sd.Name)
+				if e != nil {
+					return e
+				}
+				port, e := dev.PortByName(sd.Port)
+				if e != nil {
+					return e
+				}
+				return dev.SetActivePort(port.Path())
+			})
+		}
+		if vol := fmt.Sprint(sd.Volume); sd.Volume != nil && vol != curVolume {
+			plan.add(object, "Volume", curVolume, vol, func() error {
+				dev, e := byName(sd.Name)
+				if e != nil {
+					return e
+				}
+				return dev.Set("Volume", sd.Volume)
+			})
+		}
+		if mute := fmt.Sprint(sd.Mute); mute != curMute {
+			plan.add(object, "Mute", curMute, mute, func() error {
+				dev, e := byName(sd.Name)
+				if e != nil {
+					return e
+				}
+				return dev.Set("Mute", sd.Mute)
+			})
+		}
+	}
+}
+
+func (pulse *Client) planFallback(plan *ScenePlan, property string, byName func(string) (*Device, error), name string, pending bool) {
+	if name == "" {
+		return
+	}
+	current := ""
+	if path, e := pulse.Core().ObjectPath(property); e == nil {
+		current, _ = pulse.TypedDevice(path).Name()
+	}
+	if name == current {
+		return
+	}
+	if _, e := byName(name); e != nil && !pending {
+		plan.Missing = append(plan.Missing, property+" "+name)
+		return
+	}
+	plan.add("core", property, current, name, func() error {
+		dev, e := byName(name)
+		if e != nil {
+			return e
+		}
+		return pulse.Core().Set(property, dev.Path())
+	})
+}
+
+// planStreams plans the changes of all live streams matching the scene streams.
+// Scene streams without live match are ignored, the applications may not run.
+//
+func (pulse *Client) planStreams(plan *ScenePlan, streams []SceneStream) error {
+	type live struct {
+		path   dbus.ObjectPath
+		props  PropertyList
+		record bool
+	}
+	var lives []live
+	for _, list := range []string{"PlaybackStreams", "RecordStreams"} {
+		paths, e := pulse.Core().ListPath(list)
+		if e != nil {
+			return e
+		}
+		for _, path := range paths {
+			props, e := pulse.TypedStream(path).PropertyList()
+			if e == nil {
+				lives = append(lives, live{path, props, list == "RecordStreams"})
+			}
+		}
+	}
+
+	for _, ss := range streams {
+		ss := ss
+		byName := pulse.SinkByName
+		kind := "playback"
+		if ss.Record {
+			byName = pulse.SourceByName
+			kind = "record"
+		}
+
+		for _, lv := range lives {
+			if lv.record != ss.Record || !ss.Matches(lv.props) {
+				continue
+			}
+			stream := pulse.TypedStream(lv.path)
+			object := fmt.Sprintf("%s stream %s (%s)", kind, describeStream(lv.props), lv.path)
+
+			if ss.Device != "" {
+				current := ""
+				if dev, e := stream.Device(); e == nil {
+					current, _ = pulse.TypedDevice(dev).Name()
+				}
+				if current != ss.Device {
+					plan.add(object, "Move", current, ss.Device, func() error {
+						dev, e := byName(ss.Device)
+						if e != nil {
+							return e
+						}
+						return stream.Move(dev.Path())
+					})
+				}
+			}
+			if ss.Volume != nil {
+				vol, _ := stream.ListUint32("Volume")
+				if current, want := fmt.Sprint(vol), fmt.Sprint(ss.Volume); current != want {
+					plan.add(object, "Volume", current, want, func() error { return stream.Set("Volume", ss.Volume) })
+				}
+			}
+			if ss.Mute != nil {
+				mute, _ := stream.Bool("Mute")
+				if mute != *ss.Mute {
+					plan.add(object, "Mute", fmt.Sprint(mute), fmt.Sprint(*ss.Mute), func() error { return stream.Set("Mute", *ss.Mute) })
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// describeStream returns a short description of a stream from its properties.
+//
+func describeStream(props PropertyList) string {
+	var strs []string
+	for _, key := range SceneStreamKeys {
+		if value := props.String(key); value != "" {
+			strs = append(strs, value)
+		}
+	}
+	return strings.Join(strs, "/")
+}
+
+// ApplyScene plans and applies a scene.
+//
+func (pulse *Client) ApplyScene(scene *Scene) (errs []error) {
+	plan, e := pulse.PlanScene(scene)
+	if e != nil {
+		return []error{e}
+	}
+	return plan.Apply()
+}
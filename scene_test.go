@@ -0,0 +1,226 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSceneJSON(t *testing.T) {
+	mute := true
+	scene := &pulseaudio.Scene{
+		FallbackSink: "alsa_output.usb",
+		Cards:        []pulseaudio.SceneCard{{Name: "alsa_card.usb", Profile: "output:analog-stereo"}},
+		Sinks:        []pulseaudio.SceneDevice{{Name: "alsa_output.usb", Port: "analog-output", Volume: []uint32{65536, 32768}}},
+		Streams: []pulseaudio.SceneStream{{
+			Match:  map[string]string{pulseaudio.PropApplicationName: "player"},
+			Device: "alsa_output.usb",
+			Mute:   &mute,
+		}},
+	}
+
+	data, e := json.Marshal(scene)
+	if e != nil {
+		t.Fatal(e)
+	}
+	got := &pulseaudio.Scene{}
+	if e = json.Unmarshal(data, got); e != nil {
+		t.Fatal(e)
+	}
+	if !reflect.DeepEqual(got, scene) {
+		t.Errorf("json round trip: got %+v, want %+v", got, scene)
+	}
+}
+
+func TestSceneStreamMatches(t *testing.T) {
+	props := pulseaudio.PropertyList{}
+	props.SetString(pulseaudio.PropApplicationName, "player")
+	props.SetString(pulseaudio.PropMediaRole, "music")
+
+	for _, test := range []struct {
+		match map[string]string
+		want  bool
+	}{
+		{map[string]string{pulseaudio.PropApplicationName: "player"}, true},
+		{map[string]string{pulseaudio.PropApplicationName: "player", pulseaudio.PropMediaRole: "music"}, true},
+		{map[string]string{pulseaudio.PropApplicationName: "player", pulseaudio.PropMediaRole: "phone"}, false},
+		{map[string]string{pulseaudio.PropApplicationID: ""}, true},
+		{nil, false},
+	} {
+		if got := (pulseaudio.SceneStream{Match: test.match}).Matches(props); got != test.want {
+			t.Errorf("match %v: got %v, want %v", test.match, got, test.want)
+		}
+	}
+}
+
+func TestScenePlanString(t *testing.T) {
+	plan := pulseaudio.ScenePlan{
+		Changes: []pulseaudio.SceneChange{
+			{Object: "card alsa_card.usb", Property: "ActiveProfile", From: "off", To: "output:analog-stereo"},
+			{Object: "sink alsa_output.usb", Property: "Volume", To: "[65536]"},
+		},
+		Missing: []string{"card alsa_card.hdmi"},
+	}
+	want := "card alsa_card.usb: ActiveProfile off -> output:analog-stereo\n" +
+		"sink alsa_output.usb: Volume ? -> [65536]\n" +
+		"card alsa_card.hdmi: missing, skipped\n"
+	if got := plan.String(); got != want {
+		t.Errorf("plan: got\n%s\nwant\n%s", got, want)
+	}
+}
+
+// newSceneTest returns a fake server with a card, a sink, a source and three
+// streams. The fallback sink is unset.
+func newSceneTest() (*pulseaudio.Client, *fakeServer) {
+	pulse := pulseaudio.NewReplayClient()
+	fs := newFakeServer(pulse)
+	names := map[string]dbus.ObjectPath{
+		"usb": "/card0", "off": "/card0/profile0", "output:analog-stereo": "/card0/profile1",
+		"speakers": "/sink0", "lineout": "/sink0/port0", "headphones": "/sink0/port1",
+		"mic": "/source0",
+	}
+	for name, path := range names {
+		fs.set(path, "Name", name)
+	}
+	byName := func(path dbus.ObjectPath, method string, args ...interface{}) ([]interface{}, error) {
+		if found, ok := names[args[0].(string)]; ok {
+			return []interface{}{found}, nil
+		}
+		return nil, dbus.Error{Name: "org.PulseAudio.Core1.NoSuchEntityError", Body: []interface{}{"no " + args[0].(string)}}
+	}
+	for _, method := range []string{"GetCardByName", "GetSinkByName", "GetSourceByName", "GetProfileByName", "GetPortByName"} {
+		fs.methods[method] = byName
+	}
+
+	fs.set(pulseaudio.DbusPath, "FallbackSource", dbus.ObjectPath("/source0"))
+	fs.set(pulseaudio.DbusPath, "PlaybackStreams", []dbus.ObjectPath{"/stream0", "/stream1"})
+	fs.set(pulseaudio.DbusPath, "RecordStreams", []dbus.ObjectPath{"/stream2"})
+	fs.set("/card0", "ActiveProfile", dbus.ObjectPath("/card0/profile0"))
+	fs.set("/sink0", "ActivePort", dbus.ObjectPath("/sink0/port0"))
+	for path, value := range map[dbus.ObjectPath]map[string]interface{}{
+		"/sink0":   {"Volume": []uint32{65536, 65536}, "Mute": false},
+		"/source0": {"Volume": []uint32{65536}, "Mute": false},
+		"/stream0": {"Volume": []uint32{65536, 65536}, "Mute": false, "Device": dbus.ObjectPath("/sink0"), "PropertyList": sceneProps("player", "music")},
+		"/stream1": {"Volume": []uint32{65536, 65536}, "Mute": false, "Device": dbus.ObjectPath("/sink0"), "PropertyList": sceneProps("browser", "video")},
+		"/stream2": {"Volume": []uint32{65536}, "Device": dbus.ObjectPath("/source0"), "PropertyList": sceneProps("player", "")},
+	} {
+		for name, value := range value {
+			fs.set(path, name, value)
+		}
+	}
+	return pulse, fs
+}
+
+func sceneProps(app, role string) map[string][]byte {
+	props := map[string][]byte{pulseaudio.PropApplicationName: []byte(app + "\x00")}
+	if role != "" {
+		props[pulseaudio.PropMediaRole] = []byte(role + "\x00")
+	}
+	return props
+}
+
+// setCalls returns the properties set in the calls.
+func setCalls(calls []string) (sets []string) {
+	for _, call := range calls {
+		if strings.Contains(call, " Set ") {
+			sets = append(sets, call)
+		}
+	}
+	return sets
+}
+
+func TestPlanScene(t *testing.T) {
+	pulse, fs := newSceneTest()
+	mute := true
+	plan, e := pulse.PlanScene(&pulseaudio.Scene{
+		FallbackSink:   "speakers",
+		FallbackSource: "mic",
+		Cards: []pulseaudio.SceneCard{
+			{Name: "usb", Profile: "off"},
+			{Name: "hdmi", Profile: "output:hdmi-stereo"},
+		},
+		Sinks: []pulseaudio.SceneDevice{
+			{Name: "speakers", Port: "headphones", Volume: []uint32{32768, 32768}},
+			{Name: "usbsink", Volume: []uint32{65536}},
+		},
+		Sources: []pulseaudio.SceneDevice{{Name: "mic", Volume: []uint32{65536}}},
+		Streams: []pulseaudio.SceneStream{
+			{Match: map[string]string{pulseaudio.PropApplicationName: "player"}, Device: "speakers", Volume: []uint32{65536, 65536}, Mute: &mute},
+			{Record: true, Match: map[string]string{pulseaudio.PropApplicationName: "player"}, Volume: []uint32{32768}},
+			{Match: map[string]string{pulseaudio.PropApplicationName: "game"}, Mute: &mute},
+		},
+	})
+	if e != nil {
+		t.Fatal("plan:", e)
+	}
+
+	// Unchanged values are skipped, missing devices are reported, the unset
+	// fallback sink is planned, streams are matched by properties and kind.
+	want := "sink speakers: ActivePort lineout -> headphones\n" +
+		"sink speakers: Volume [65536 65536] -> [32768 32768]\n" +
+		"core: FallbackSink ? -> speakers\n" +
+		"playback stream player/music (/stream0): Mute false -> true\n" +
+		"record stream player (/stream2): Volume [65536] -> [32768]\n" +
+		"card hdmi: missing, skipped\n" +
+		"sink usbsink: missing, skipped\n"
+	if got := plan.String(); got != want {
+		t.Errorf("plan: got\n%s\nwant\n%s", got, want)
+	}
+
+	fs.takeCalls()
+	if errs := plan.Apply(); len(errs) > 0 {
+		t.Error("apply:", errs)
+	}
+	wantCalls := []string{
+		"/sink0 Set ActivePort /sink0/port1",
+		"/sink0 Set Volume [32768 32768]",
+		pulseaudio.DbusPath + " Set FallbackSink /sink0",
+		"/stream0 Set Mute true",
+		"/stream2 Set Volume [32768]",
+	}
+	if calls := setCalls(fs.takeCalls()); !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("apply: got calls %v, want %v", calls, wantCalls)
+	}
+
+	// Applied, the scene has no more changes.
+	plan, e = pulse.PlanScene(&pulseaudio.Scene{FallbackSink: "speakers", Sinks: []pulseaudio.SceneDevice{{Name: "speakers", Port: "headphones"}}})
+	if e != nil || len(plan.Changes) > 0 {
+		t.Errorf("applied: got plan %q (error %v), want no changes", plan, e)
+	}
+}
+
+func TestPlanSceneProfileChange(t *testing.T) {
+	pulse, fs := newSceneTest()
+	plan, e := pulse.PlanScene(&pulseaudio.Scene{
+		FallbackSink: "usbsink",
+		Cards:        []pulseaudio.SceneCard{{Name: "usb", Profile: "output:analog-stereo"}},
+		Sinks:        []pulseaudio.SceneDevice{{Name: "usbsink", Volume: []uint32{32768}, Mute: true}},
+	})
+	if e != nil {
+		t.Fatal("plan:", e)
+	}
+
+	// Devices missing while a profile change is pending may be created by the
+	// change, their settings are planned without current value.
+	want := "card usb: ActiveProfile off -> output:analog-stereo\n" +
+		"sink usbsink: Volume ? -> [32768]\n" +
+		"sink usbsink: Mute ? -> true\n" +
+		"core: FallbackSink ? -> usbsink\n"
+	if got := plan.String(); got != want {
+		t.Errorf("plan: got\n%s\nwant\n%s", got, want)
+	}
+
+	// The profile change is applied, the device still missing fails.
+	fs.takeCalls()
+	if errs := plan.Apply(); len(errs) != 3 {
+		t.Errorf("apply: got errors %v, want 3 for the missing sink", errs)
+	}
+	if calls, want := setCalls(fs.takeCalls()), []string{"/card0 Set ActiveProfile /card0/profile1"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("apply: got calls %v, want %v", calls, want)
+	}
+}
@@ -0,0 +1,43 @@
+package pulseaudio
+
+import "github.com/godbus/dbus"
+
+// Stream is a typed access to a pulseaudio stream (playback or record).
+// See Client.Stream for the properties list.
+//
+// The generic Object methods are still available for other properties.
+//
+type Stream struct {
+	*Object
+	pulse *Client
+}
+
+// TypedStream returns a typed access to a pulseaudio stream.
+//
+func (pulse *Client) TypedStream(path dbus.ObjectPath) *Stream {
+	return &Stream{pulse.Stream(path), pulse}
+}
+
+// Device returns the device the stream is connected to.
+//
+func (stream *Stream) Device() (dbus.ObjectPath, error) {
+	return stream.ObjectPath("Device")
+}
+
+// PropertyList returns the stream property list.
+//
+func (stream *Stream) PropertyList() (PropertyList, error) {
+	return stream.Object.PropertyList("PropertyList")
+}
+
+// Move moves the stream to another device.
+//
+func (stream *Stream) Move(device dbus.ObjectPath) error {
+	return stream.Call(stream.prefix+".Move", 0, device).Err
+}
+
+// Kill kills the stream.
+//
+func (stream *Stream) Kill() error {
+	return stream.Call(stream.prefix+".Kill", 0).Err
+}
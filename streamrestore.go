@@ -0,0 +1,185 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+)
+
+// Stream restore extension Dbus objects paths.
+// Requires module-stream-restore (loaded by default).
+//
+const (
+	StreamRestoreInterface = "org.PulseAudio.Ext.StreamRestore1"
+	StreamRestorePath      = "/org/pulseaudio/stream_restore1"
+)
+
+const restoreEntryInterface = StreamRestoreInterface + ".RestoreEntry"
+
+// ChannelVolume is the volume of a single channel.
+//
+type ChannelVolume struct {
+	Channel uint32 // Channel position.
+	Volume  uint32
+}
+
+// StreamRestore controls the stream restore database, the persistent volume,
+// mute and device settings applied to new streams.
+//
+// Methods list:
+//   AddEntry         Adds or updates an entry.
+//     string:          Name of the entry (ex: "sink-input-by-application-name:Firefox").
+//     string:          Device name, can be empty.
+//     []ChannelVolume: Volume, can be empty.
+//     bool:            Mute.
+//     bool:            Apply immediately to matching streams.
+//     out: ObjectPath: Entry object
+//   GetEntryByName   Finds an entry by its name.
+//     string:          Name of the entry.
+//     out: ObjectPath: Entry object
+//
+// Properties list:
+//   Uint32
+//     !InterfaceRevision  The version of the extension interface.
+//
+//   ListPath
+//     Entries             All entries in the stream restore database.
+//
+func (pulse *Client) StreamRestore() *StreamRestore {
+	return &StreamRestore{pulse.newObject(StreamRestoreInterface, StreamRestorePath), pulse}
+}
+
+// RestoreEntry controls an entry of the stream restore database.
+//
+// Methods list:
+//   Remove    Removes the entry from the database.
+//
+// Properties list:
+//   Uint32
+//     Index      The entry index.
+//
+//   String
+//     Name       The entry name.
+//     Device RW  The device name where the matching streams are routed. Empty if not set.
+//
+//   Boolean
+//     Mute   RW  Whether or not the matching streams are muted.
+//
+//   []ChannelVolume
+//     Volume RW  The volume of the matching streams. Empty if not set.
+//
+func (pulse *Client) RestoreEntry(path dbus.ObjectPath) *RestoreEntry {
+	return &RestoreEntry{pulse.newObject(restoreEntryInterface, path)}
+}
+
+// StreamRestore is a typed access to the stream restore extension.
+// See Client.StreamRestore for the properties list.
+//
+type StreamRestore struct {
+	*Object
+	pulse *Client
+}
+
+// Entries returns all entries of the stream restore database.
+//
+func (sr *StreamRestore) Entries() ([]*RestoreEntry, error) {
+	paths, e := sr.ListPath("Entries")
+	if e != nil {
+		return nil, e
+	}
+	entries := make([]*RestoreEntry, len(paths))
+	for i, path := range paths {
+		entries[i] = sr.pulse.RestoreEntry(path)
+	}
+	return entries, nil
+}
+
+// AddEntry adds an entry to the database, or updates it if the name exists.
+// device and volume can be empty to leave them unset.
+// If apply is true, the settings are applied immediately to matching streams.
+//
+func (sr *StreamRestore) AddEntry(name, device string, volume []ChannelVolume, mute, apply bool) (*RestoreEntry, error) {
+	if volume == nil {
+		volume = []ChannelVolume{}
+	}
+	var path dbus.ObjectPath
+	e := sr.Call(sr.prefix+".AddEntry", 0, name, device, volume, mute, apply).Store(&path)
+	if e != nil {
+		return nil, e
+	}
+	return sr.pulse.RestoreEntry(path), nil
+}
+
+// GetEntryByName finds an entry by its name.
+//
+func (sr *StreamRestore) GetEntryByName(name string) (*RestoreEntry, error) {
+	var path dbus.ObjectPath
+	e := sr.Call(sr.prefix+".GetEntryByName", 0, name).Store(&path)
+	if e != nil {
+		return nil, e
+	}
+	return sr.pulse.RestoreEntry(path), nil
+}
+
+// RestoreEntry is a typed access to a stream restore database entry.
+// See Client.RestoreEntry for the properties list.
+//
+type RestoreEntry struct {
+	*Object
+}
+
+// Index returns the entry index.
+//
+func (entry *RestoreEntry) Index() (uint32, error) {
+	return entry.Uint32("Index")
+}
+
+// Name returns the entry name.
+//
+func (entry *RestoreEntry) Name() (string, error) {
+	return entry.String("Name")
+}
+
+// Device returns the device name of the entry. Empty if not set.
+//
+func (entry *RestoreEntry) Device() (string, error) {
+	return entry.String("Device")
+}
+
+// SetDevice sets the device name of the entry. Empty to unset.
+//
+func (entry *RestoreEntry) SetDevice(device string) error {
+	return entry.Set("Device", device)
+}
+
+// Volume returns the volume of the entry. Empty if not set.
+//
+func (entry *RestoreEntry) Volume() (val []ChannelVolume, e error) {
+	e = entry.Get("Volume", &val)
+	return val, e
+}
+
+// SetVolume sets the volume of the entry. Empty to unset.
+//
+func (entry *RestoreEntry) SetVolume(volume []ChannelVolume) error {
+	if volume == nil {
+		volume = []ChannelVolume{}
+	}
+	return entry.Set("Volume", volume)
+}
+
+// Mute returns the mute state of the entry.
+//
+func (entry *RestoreEntry) Mute() (bool, error) {
+	return entry.Bool("Mute")
+}
+
+// SetMute sets the mute state of the entry.
+//
+func (entry *RestoreEntry) SetMute(mute bool) error {
+	return entry.Set("Mute", mute)
+}
+
+// Remove removes the entry from the database.
+//
+func (entry *RestoreEntry) Remove() error {
+	return entry.Call(entry.prefix+".Remove", 0).Err
+}
@@ -0,0 +1,51 @@
+package pulseaudio_test
+
+import (
+	"github.com/godbus/dbus"
+
+	"github.com/sqp/pulseaudio"
+
+	"reflect"
+	"testing"
+)
+
+type restoreClient struct {
+	volume []pulseaudio.ChannelVolume
+	mute   bool
+}
+
+func (cl *restoreClient) RestoreEntryVolumeUpdated(path dbus.ObjectPath, volume []pulseaudio.ChannelVolume) {
+	cl.volume = volume
+}
+
+func (cl *restoreClient) RestoreEntryMuteUpdated(path dbus.ObjectPath, mute bool) {
+	cl.mute = mute
+}
+
+func TestStreamRestoreHooks(t *testing.T) {
+	hooker := pulseaudio.NewHooker()
+	hooker.AddEvents(pulseaudio.StreamRestoreEvents)
+
+	client := &restoreClient{}
+	tolisten := hooker.Register(client)
+	if len(tolisten) != 2 {
+		t.Fatalf("register: got %v, want 2 events to listen", tolisten)
+	}
+
+	const entryInterface = pulseaudio.StreamRestoreInterface + ".RestoreEntry"
+	path := dbus.ObjectPath(pulseaudio.StreamRestorePath + "/entry0")
+
+	hooker.Call(entryInterface+".VolumeUpdated", &dbus.Signal{
+		Path: path,
+		Body: []interface{}{[][]interface{}{{uint32(1), uint32(100)}, {uint32(2), uint32(200)}}},
+	})
+	want := []pulseaudio.ChannelVolume{{Channel: 1, Volume: 100}, {Channel: 2, Volume: 200}}
+	if !reflect.DeepEqual(client.volume, want) {
+		t.Errorf("volume updated: got %v, want %v", client.volume, want)
+	}
+
+	hooker.Call(entryInterface+".MuteUpdated", &dbus.Signal{Path: path, Body: []interface{}{true}})
+	if !client.mute {
+		t.Error("mute updated: not called")
+	}
+}
@@ -0,0 +1,191 @@
+package pulseaudio
+
+import (
+	"github.com/godbus/dbus"
+
+	"math"
+)
+
+// VolumeController implements the volume keys actions on the fallback sink:
+// step up, step down and mute toggle.
+//
+// The fallback sink is resolved at each call, so the controller follows the
+// default device changes. Steps and the ceiling are relative to the device
+// BaseVolume when it has one (the volume without amplification), or VolumeNorm.
+// The balance between channels is preserved.
+//
+// Each action calls OnChange once with the new state, to display an OSD.
+//
+//   vc := pulseaudio.NewVolumeController(pulse)
+//   vc.Max = 1.5
+//   vc.OnChange = func(ev pulseaudio.VolumeEvent) { osd.Show(ev.Percent, ev.Mute) }
+//   vc.Up()
+//
+type VolumeController struct {
+	Step             float64           // Volume change by step, as ratio of the reference volume. Default 0.05 (5%).
+	Max              float64           // Volume ceiling, as ratio of the reference volume. Default 1 (100%).
+	UnmuteOnIncrease bool              // Unmute the device when the volume is increased. Default true.
+	OnChange         func(VolumeEvent) // Called after each action. Optional.
+
+	pulse *Client
+}
+
+// VolumeEvent describes the device state after a volume controller action.
+//
+type VolumeEvent struct {
+	Device  dbus.ObjectPath
+	Volume  []uint32
+	Percent int  // Highest channel volume in percent of the reference volume.
+	Mute    bool // Device muted.
+	Limited bool // The step was reduced by the ceiling or silence.
+}
+
+// NewVolumeController creates a volume controller with default settings.
+//
+func NewVolumeController(pulse *Client) *VolumeController {
+	return &VolumeController{
+		Step:             0.05,
+		Max:              1,
+		UnmuteOnIncrease: true,
+		pulse:            pulse,
+	}
+}
+
+// Up increases the fallback sink volume by one step.
+//
+func (vc *VolumeController) Up() (VolumeEvent, error) {
+	return vc.change(1)
+}
+
+// Down decreases the fallback sink volume by one step.
+//
+func (vc *VolumeController) Down() (VolumeEvent, error) {
+	return vc.change(-1)
+}
+
+// ToggleMute toggles the fallback sink mute state.
+//
+func (vc *VolumeController) ToggleMute() (VolumeEvent, error) {
+	dev, ref, e := vc.sink()
+	if e != nil {
+		return VolumeEvent{}, e
+	}
+	mute, e := dev.Bool("Mute")
+	if e != nil {
+		return VolumeEvent{}, e
+	}
+	if e = dev.Set("Mute", !mute); e != nil {
+		return VolumeEvent{}, e
+	}
+	vol, e := dev.ListUint32("Volume")
+	if e != nil {
+		return VolumeEvent{}, e
+	}
+	return vc.emit(dev, ref, vol, !mute, false), nil
+}
+
+// change steps the fallback sink volume in the given direction.
+//
+func (vc *VolumeController) change(direction float64) (VolumeEvent, error) {
+	dev, ref, e := vc.sink()
+	if e != nil {
+		return VolumeEvent{}, e
+	}
+	vol, e := dev.ListUint32("Volume")
+	if e != nil {
+		return VolumeEvent{}, e
+	}
+	mute, e := dev.Bool("Mute")
+	if e != nil {
+		return VolumeEvent{}, e
+	}
+
+	ceiling := clampVolume(vc.Max * float64(ref))
+	newvol, limited := StepVolume(vol, direction*vc.Step*float64(ref), ceiling)
+	if !equalUint32(newvol, vol) {
+		if e = dev.Set("Volume", newvol); e != nil {
+			return VolumeEvent{}, e
+		}
+	}
+
+	if direction > 0 && mute && vc.UnmuteOnIncrease {
+		if e = dev.Set("Mute", false); e != nil {
+			return VolumeEvent{}, e
+		}
+		mute = false
+	}
+	return vc.emit(dev, ref, newvol, mute, limited), nil
+}
+
+// sink returns the fallback sink and its reference volume.
+//
+func (vc *VolumeController) sink() (*Device, uint32, error) {
+	path, e := vc.pulse.Core().ObjectPath("FallbackSink")
+	if e != nil {
+		return nil, 0, e
+	}
+	dev := vc.pulse.TypedDevice(path)
+	ref, e := dev.Uint32("BaseVolume")
+	if e != nil || ref == 0 {
+		ref = VolumeNorm // Optional property.
+	}
+	return dev, ref, nil
+}
+
+func (vc *VolumeController) emit(dev *Device, ref uint32, vol []uint32, mute, limited bool) VolumeEvent {
+	var max uint32
+	for _, v := range vol {
+		if v > max {
+			max = v
+		}
+	}
+	ev := VolumeEvent{
+		Device:  dev.Path(),
+		Volume:  vol,
+		Percent: int(math.Round(float64(max) * 100 / float64(ref))),
+		Mute:    mute,
+		Limited: limited,
+	}
+	if vc.OnChange != nil {
+		vc.OnChange(ev)
+	}
+	return ev
+}
+
+// StepVolume changes the loudest channel volume by delta, and scales the other
+// channels to preserve the balance. The result is kept between silence and
+// ceiling, limited is set when the step was reduced. The ceiling only limits
+// increases, a volume already over it can still be lowered step by step.
+//
+// Silent channels can't keep a balance: when all channels are silent, they are
+// all set to the new volume.
+//
+func StepVolume(vol []uint32, delta float64, ceiling uint32) (out []uint32, limited bool) {
+	var max uint32
+	for _, v := range vol {
+		if v > max {
+			max = v
+		}
+	}
+
+	if max >= ceiling && delta > 0 { // Already at or over the ceiling, don't lower it.
+		return append([]uint32(nil), vol...), true
+	}
+	target := float64(max) + delta
+	switch {
+	case delta > 0 && target > float64(ceiling):
+		target, limited = float64(ceiling), true
+	case target < 0:
+		target, limited = 0, true
+	}
+
+	out = make([]uint32, len(vol))
+	for i, v := range vol {
+		if max == 0 {
+			out[i] = clampVolume(target)
+			continue
+		}
+		out[i] = clampVolume(float64(v) * target / float64(max))
+	}
+	return out, limited
+}
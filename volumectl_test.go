@@ -0,0 +1,35 @@
+package pulseaudio_test
+
+import (
+	"github.com/sqp/pulseaudio"
+
+	"reflect"
+	"testing"
+)
+
+func TestStepVolume(t *testing.T) {
+	const step = 3277 // 5%.
+	for _, test := range []struct {
+		name    string
+		vol     []uint32
+		delta   float64
+		ceiling uint32
+		want    []uint32
+		limited bool
+	}{
+		{"up", []uint32{32768, 32768}, step, 65536, []uint32{36045, 36045}, false},
+		{"down", []uint32{32768}, -step, 65536, []uint32{29491}, false},
+		{"balance", []uint32{65536, 32768}, -6554, 65536, []uint32{58982, 29491}, false},
+		{"ceiling", []uint32{64000, 32000}, step, 65536, []uint32{65536, 32768}, true},
+		{"silence", []uint32{1000, 500}, -step, 65536, []uint32{0, 0}, true},
+		{"from silence", []uint32{0, 0}, step, 65536, []uint32{3277, 3277}, false},
+		{"over ceiling", []uint32{98304}, step, 65536, []uint32{98304}, true},
+		{"down over ceiling", []uint32{98304}, -step, 65536, []uint32{95027}, false},
+		{"at ceiling", []uint32{65536, 0}, step, 65536, []uint32{65536, 0}, true},
+	} {
+		got, limited := pulseaudio.StepVolume(test.vol, test.delta, test.ceiling)
+		if !reflect.DeepEqual(got, test.want) || limited != test.limited {
+			t.Errorf("%s: got %v, %v, want %v, %v", test.name, got, limited, test.want, test.limited)
+		}
+	}
+}